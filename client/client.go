@@ -0,0 +1,375 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client provides a client for the Aite service for use within tests,
+// such that impairments can be applied without constructing requests by hand, for
+// example:
+//
+//	c := client.NewForTest(t, apb.NewAiteClient(conn))
+//	if _, err := c.Impair(ctx, "eth1", client.WithLatency(50*time.Millisecond), client.WithLoss(1.5)); err != nil {
+//		t.Fatalf("cannot impair eth1, %v", err)
+//	}
+//
+// Each interface changed by a client created by NewForTest is restored when the
+// test completes.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// Client is a client for the Aite service, which records the state of each
+// interface before its first change such that it can later be restored.
+type Client struct {
+	c apb.AiteClient
+	// t is the test within which the client is used, nil if the client is not
+	// used within a test.
+	t testing.TB
+
+	// mu protects prior.
+	mu sync.Mutex
+	// prior stores the state of each interface that the client has changed
+	// before its first change to it, keyed by interface name.
+	prior map[string]apb.InterfaceState
+}
+
+// New returns a client that sends requests to the Aite service using c.
+func New(c apb.AiteClient) *Client {
+	return &Client{
+		c:     c,
+		prior: map[string]apb.InterfaceState{},
+	}
+}
+
+// NewForTest returns a client that sends requests to the Aite service using c,
+// which restores each interface that it changes once the test t, and all of its
+// subtests, complete.
+func NewForTest(t testing.TB, c apb.AiteClient) *Client {
+	cl := New(c)
+	cl.t = t
+	return cl
+}
+
+// Option specifies a parameter of a change to an interface, returning an error if
+// the parameter is invalid.
+type Option func(*apb.SetInterfaceRequest) error
+
+// WithLatency adds the specified latency to each packet, which must be at least
+// one millisecond, or zero.
+func WithLatency(d time.Duration) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		msec, err := durationMsec("latency", d)
+		if err != nil {
+			return err
+		}
+		r.Params.LatencyMsec = msec
+		return nil
+	}
+}
+
+// WithJitter varies the latency of each packet by up to the specified duration,
+// which must be at least one millisecond, or zero.
+func WithJitter(d time.Duration) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		msec, err := durationMsec("jitter", d)
+		if err != nil {
+			return err
+		}
+		r.Params.JitterMsec = msec
+		return nil
+	}
+}
+
+// durationMsec returns the duration d of the named parameter in milliseconds,
+// truncating any fraction of a millisecond. It returns an error if d is negative,
+// or is non-zero but less than one millisecond, such that it would otherwise be
+// silently dropped.
+func durationMsec(param string, d time.Duration) (uint32, error) {
+	if d < 0 || (d > 0 && d < time.Millisecond) {
+		return 0, fmt.Errorf("%s must be zero or at least 1ms, got: %s", param, d)
+	}
+	return uint32(d.Milliseconds()), nil
+}
+
+// WithLoss drops the specified percentage of packets, which may be fractional to
+// a precision of one part per million.
+func WithLoss(pct float64) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		p, ppm, err := LossParams(pct)
+		if err != nil {
+			return err
+		}
+		r.Params.LossPct, r.Params.LossPpm = p, ppm
+		return nil
+	}
+}
+
+// LossParams returns the loss_pct and loss_ppm parameters that drop the specified
+// percentage of packets. Loss that is not a whole number of percent is expressed
+// in parts per million. It returns an error if pct is not 0 <= pct <= 100, or is
+// non-zero but less than one part per million.
+func LossParams(pct float64) (uint32, uint32, error) {
+	if !(pct >= 0 && pct <= 100) {
+		return 0, 0, fmt.Errorf("loss must be 0 <= loss <= 100 percent, got: %g", pct)
+	}
+	if pct == math.Trunc(pct) {
+		return uint32(pct), 0, nil
+	}
+	ppm := math.Round(pct * 1e4)
+	if ppm == 0 {
+		return 0, 0, fmt.Errorf("loss must be at least one part per million, got: %g percent", pct)
+	}
+	return 0, uint32(ppm), nil
+}
+
+// WithDuplicate duplicates the specified percentage of packets.
+func WithDuplicate(pct uint32) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		r.Params.DuplicatePct = pct
+		return nil
+	}
+}
+
+// WithCorrupt corrupts the specified percentage of packets.
+func WithCorrupt(pct uint32) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		r.Params.CorruptPct = pct
+		return nil
+	}
+}
+
+// WithReorder sends the specified percentage of packets immediately, such that
+// they are reordered with respect to those that are delayed.
+func WithReorder(pct uint32) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		r.Params.ReorderPct = pct
+		return nil
+	}
+}
+
+// WithRate limits the interface to the specified number of bits per second.
+func WithRate(bps uint64) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		r.Params.RateBps = bps
+		return nil
+	}
+}
+
+// WithDelayDistribution draws the latency of each packet from the specified
+// distribution.
+func WithDelayDistribution(d apb.DelayDistribution) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		r.Params.DelayDistribution = d
+		return nil
+	}
+}
+
+// WithDirection applies the impairments to traffic in the specified direction.
+func WithDirection(d apb.Direction) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		r.Params.Direction = d
+		return nil
+	}
+}
+
+// WithParams merges the specified parameters into those of the change, such that
+// parameters without a corresponding option can be specified.
+func WithParams(p *apb.InterfaceStateParams) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		proto.Merge(r.Params, p)
+		return nil
+	}
+}
+
+// WithProfile applies the named profile that was loaded by the Aite server, which
+// the other options override.
+func WithProfile(name string) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		r.ProfileName = name
+		return nil
+	}
+}
+
+// WithFlow applies the impairments specified by opts to the packets that match m,
+// in place of the impairments applied to the interface.
+func WithFlow(m *apb.FlowMatch, opts ...Option) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		f := &apb.SetInterfaceRequest{Params: &apb.InterfaceStateParams{}}
+		for _, o := range opts {
+			if err := o(f); err != nil {
+				return fmt.Errorf("invalid flow impairment, %w", err)
+			}
+		}
+		r.Flows = append(r.Flows, &apb.FlowImpairment{Match: m, Params: f.Params})
+		return nil
+	}
+}
+
+// WithDuration has Aite restore the interface once the specified duration has
+// elapsed, such that it is restored even if the client fails. The duration must be
+// at least one millisecond, or zero.
+func WithDuration(d time.Duration) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		msec, err := durationMsec("duration", d)
+		if err != nil {
+			return err
+		}
+		r.DurationMsec = msec
+		return nil
+	}
+}
+
+// WithLease makes the change under the lease with the specified ID.
+func WithLease(id string) Option {
+	return func(r *apb.SetInterfaceRequest) error {
+		r.LeaseId = id
+		return nil
+	}
+}
+
+// Impair brings the interface with the specified name up, applying the impairments
+// specified by opts.
+func (c *Client) Impair(ctx context.Context, name string, opts ...Option) (*apb.SetInterfaceResponse, error) {
+	return c.set(ctx, name, apb.InterfaceState_IS_UP, opts)
+}
+
+// AdminDown brings the interface with the specified name administratively down.
+func (c *Client) AdminDown(ctx context.Context, name string, opts ...Option) (*apb.SetInterfaceResponse, error) {
+	return c.set(ctx, name, apb.InterfaceState_IS_ADMIN_DOWN, opts)
+}
+
+// OperDown brings the interface with the specified name operationally down,
+// emulating a loss of carrier.
+func (c *Client) OperDown(ctx context.Context, name string, opts ...Option) (*apb.SetInterfaceResponse, error) {
+	return c.set(ctx, name, apb.InterfaceState_IS_OPER_DOWN, opts)
+}
+
+// set places the interface with the specified name into the state st, with the
+// parameters specified by opts.
+func (c *Client) set(ctx context.Context, name string, st apb.InterfaceState, opts []Option) (*apb.SetInterfaceResponse, error) {
+	req := &apb.SetInterfaceRequest{
+		Name:   name,
+		Params: &apb.InterfaceStateParams{State: st},
+	}
+	for _, o := range opts {
+		if err := o(req); err != nil {
+			return nil, fmt.Errorf("cannot set interface %s, %w", name, err)
+		}
+	}
+
+	if err := c.record(ctx, name); err != nil {
+		return nil, err
+	}
+	resp, err := c.c.SetInterface(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot set interface %s, %w", name, err)
+	}
+	return resp, nil
+}
+
+// record records the state of the interface with the specified name if the client
+// has not previously changed it, registering its restoration with the test within
+// which the client is used.
+func (c *Client) record(ctx context.Context, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.prior[name]; ok {
+		return nil
+	}
+
+	resp, err := c.c.GetInterface(ctx, &apb.GetInterfaceRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("cannot get interface %s, %w", name, err)
+	}
+	c.prior[name] = resp.GetParams().GetState()
+
+	if c.t != nil {
+		c.t.Cleanup(func() {
+			if err := c.Restore(context.Background(), name); err != nil {
+				c.t.Errorf("cannot restore interface %s, %v", name, err)
+			}
+		})
+	}
+	return nil
+}
+
+// Restore removes the impairments from the interface with the specified name, and
+// returns it to the administrative state that it was in before the client first
+// changed it. It is not an error for the client not to have changed the interface.
+func (c *Client) Restore(ctx context.Context, name string) error {
+	c.mu.Lock()
+	prior, ok := c.prior[name]
+	delete(c.prior, name)
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if _, err := c.c.ClearInterface(ctx, &apb.ClearInterfaceRequest{Name: name}); err != nil {
+		return fmt.Errorf("cannot clear interface %s, %w", name, err)
+	}
+
+	// Clearing an interface does not change its state, and hence where the state
+	// differs, it is set and the impairment that doing so installs is cleared.
+	if prior != apb.InterfaceState_IS_UP && prior != apb.InterfaceState_IS_ADMIN_DOWN {
+		return nil
+	}
+	resp, err := c.c.GetInterface(ctx, &apb.GetInterfaceRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("cannot get interface %s, %w", name, err)
+	}
+	if resp.GetParams().GetState() == prior {
+		return nil
+	}
+	if _, err := c.c.SetInterface(ctx, &apb.SetInterfaceRequest{
+		Name:   name,
+		Params: &apb.InterfaceStateParams{State: prior},
+	}); err != nil {
+		return fmt.Errorf("cannot set state of interface %s, %w", name, err)
+	}
+	if _, err := c.c.ClearInterface(ctx, &apb.ClearInterfaceRequest{Name: name}); err != nil {
+		return fmt.Errorf("cannot clear interface %s, %w", name, err)
+	}
+	return nil
+}
+
+// RestoreAll restores each interface that the client has changed, as per Restore.
+func (c *Client) RestoreAll(ctx context.Context) error {
+	c.mu.Lock()
+	names := []string{}
+	for name := range c.prior {
+		names = append(names, name)
+	}
+	c.mu.Unlock()
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if err := c.Restore(ctx, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,354 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv"
+	"github.com/openconfig/aite/srv/backend/fake"
+)
+
+// newFakeAite returns a client of an Aite server that programs a fake backend
+// with the interfaces eth0 and eth1, and which is served over an in-memory
+// connection until the test completes.
+func newFakeAite(t *testing.T) apb.AiteClient {
+	t.Helper()
+	b := fake.New()
+	b.AddLink("eth0", false)
+	b.AddLink("eth1", true)
+	s, err := srv.New(srv.WithBackend(b))
+	if err != nil {
+		t.Fatalf("cannot create server, %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	apb.RegisterAiteServer(gs, s)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("cannot dial server, %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return apb.NewAiteClient(conn)
+}
+
+// wantParams checks that the params of the interface with the specified name are
+// want.
+func wantParams(t *testing.T, c apb.AiteClient, name string, want *apb.InterfaceStateParams) {
+	t.Helper()
+	resp, err := c.GetInterface(context.Background(), &apb.GetInterfaceRequest{Name: name})
+	if err != nil {
+		t.Fatalf("GetInterface(%s): cannot get interface, %v", name, err)
+	}
+	if got := resp.GetParams(); !proto.Equal(got, want) {
+		t.Errorf("GetInterface(%s): did not get expected params, got: %s, want: %s", name, prototext.Format(got), prototext.Format(want))
+	}
+}
+
+func TestLossParams(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      float64
+		wantPct uint32
+		wantPpm uint32
+		wantErr bool
+	}{{
+		desc: "no loss",
+		in:   0,
+	}, {
+		desc:    "whole percent",
+		in:      5,
+		wantPct: 5,
+	}, {
+		desc:    "all packets",
+		in:      100,
+		wantPct: 100,
+	}, {
+		desc:    "fractional percent",
+		in:      1.5,
+		wantPpm: 15000,
+	}, {
+		desc:    "one part per million",
+		in:      0.0001,
+		wantPpm: 1,
+	}, {
+		desc:    "rounded to part per million",
+		in:      1.23456789,
+		wantPpm: 12346,
+	}, {
+		desc:    "less than one part per million",
+		in:      0.00001,
+		wantErr: true,
+	}, {
+		desc:    "negative",
+		in:      -1,
+		wantErr: true,
+	}, {
+		desc:    "more than 100 percent",
+		in:      100.5,
+		wantErr: true,
+	}, {
+		desc:    "not a number",
+		in:      math.NaN(),
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			pct, ppm, err := LossParams(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LossParams(%g): did not get expected error, got: %v, wantErr? %v", tt.in, err, tt.wantErr)
+			}
+			if pct != tt.wantPct || ppm != tt.wantPpm {
+				t.Errorf("LossParams(%g): did not get expected params, got: (%d, %d), want: (%d, %d)", tt.in, pct, ppm, tt.wantPct, tt.wantPpm)
+			}
+		})
+	}
+}
+
+func TestDurationOptions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      Option
+		want    *apb.SetInterfaceRequest
+		wantErr bool
+	}{{
+		desc: "latency",
+		in:   WithLatency(50 * time.Millisecond),
+		want: &apb.SetInterfaceRequest{Params: &apb.InterfaceStateParams{LatencyMsec: 50}},
+	}, {
+		desc: "zero latency",
+		in:   WithLatency(0),
+		want: &apb.SetInterfaceRequest{Params: &apb.InterfaceStateParams{}},
+	}, {
+		desc:    "latency less than 1ms",
+		in:      WithLatency(500 * time.Microsecond),
+		wantErr: true,
+	}, {
+		desc:    "negative latency",
+		in:      WithLatency(-time.Millisecond),
+		wantErr: true,
+	}, {
+		desc: "jitter",
+		in:   WithJitter(1500 * time.Microsecond),
+		want: &apb.SetInterfaceRequest{Params: &apb.InterfaceStateParams{JitterMsec: 1}},
+	}, {
+		desc:    "jitter less than 1ms",
+		in:      WithJitter(time.Nanosecond),
+		wantErr: true,
+	}, {
+		desc: "duration",
+		in:   WithDuration(time.Second),
+		want: &apb.SetInterfaceRequest{Params: &apb.InterfaceStateParams{}, DurationMsec: 1000},
+	}, {
+		desc:    "duration less than 1ms",
+		in:      WithDuration(999 * time.Microsecond),
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := &apb.SetInterfaceRequest{Params: &apb.InterfaceStateParams{}}
+			if err := tt.in(got); (err != nil) != tt.wantErr {
+				t.Fatalf("did not get expected error, got: %v, wantErr? %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("did not get expected request, got: %s, want: %s", prototext.Format(got), prototext.Format(tt.want))
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	up := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP}
+	adminDown := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}
+
+	tests := []struct {
+		desc string
+		name string
+		// initial is the state to which the interface is set before the client
+		// changes it, nil if it is not set.
+		initial *apb.InterfaceStateParams
+		// change makes the changes to the interface that are to be restored.
+		change func(context.Context, *Client, string) error
+		want   *apb.InterfaceStateParams
+	}{{
+		desc: "impairment removed",
+		name: "eth1",
+		change: func(ctx context.Context, c *Client, name string) error {
+			_, err := c.Impair(ctx, name, WithLatency(50*time.Millisecond), WithLoss(1.5))
+			return err
+		},
+		want: up,
+	}, {
+		desc: "admin down restored to up",
+		name: "eth1",
+		change: func(ctx context.Context, c *Client, name string) error {
+			_, err := c.AdminDown(ctx, name)
+			return err
+		},
+		want: up,
+	}, {
+		desc: "carrier restored",
+		name: "eth1",
+		change: func(ctx context.Context, c *Client, name string) error {
+			_, err := c.OperDown(ctx, name)
+			return err
+		},
+		want: up,
+	}, {
+		desc: "emulated carrier loss removed",
+		name: "eth0",
+		change: func(ctx context.Context, c *Client, name string) error {
+			_, err := c.OperDown(ctx, name)
+			return err
+		},
+		want: up,
+	}, {
+		desc:    "impaired interface restored to admin down",
+		name:    "eth1",
+		initial: adminDown,
+		change: func(ctx context.Context, c *Client, name string) error {
+			_, err := c.Impair(ctx, name, WithLatency(50*time.Millisecond))
+			return err
+		},
+		want: adminDown,
+	}, {
+		// The state before the first change is restored, rather than that
+		// between the changes.
+		desc: "state before first change restored",
+		name: "eth1",
+		change: func(ctx context.Context, c *Client, name string) error {
+			if _, err := c.AdminDown(ctx, name); err != nil {
+				return err
+			}
+			_, err := c.Impair(ctx, name, WithRate(1000000))
+			return err
+		},
+		want: up,
+	}, {
+		desc:   "interface not changed",
+		name:   "eth1",
+		change: func(context.Context, *Client, string) error { return nil },
+		want:   up,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := context.Background()
+			ac := newFakeAite(t)
+			if tt.initial != nil {
+				if _, err := ac.SetInterface(ctx, &apb.SetInterfaceRequest{Name: tt.name, Params: tt.initial}); err != nil {
+					t.Fatalf("SetInterface(): cannot set initial state, %v", err)
+				}
+			}
+
+			c := New(ac)
+			if err := tt.change(ctx, c, tt.name); err != nil {
+				t.Fatalf("cannot change interface %s, %v", tt.name, err)
+			}
+			if err := c.Restore(ctx, tt.name); err != nil {
+				t.Fatalf("Restore(%s): cannot restore interface, %v", tt.name, err)
+			}
+			wantParams(t, ac, tt.name, tt.want)
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if _, ok := c.prior[tt.name]; ok {
+				t.Errorf("Restore(%s): prior state of interface retained after restore", tt.name)
+			}
+		})
+	}
+}
+
+func TestInvalidOptionNotApplied(t *testing.T) {
+	ac := newFakeAite(t)
+	c := New(ac)
+	if _, err := c.Impair(context.Background(), "eth1", WithLatency(time.Microsecond)); err == nil {
+		t.Fatalf("Impair(): did not get expected error for invalid latency")
+	}
+	wantParams(t, ac, "eth1", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.prior) != 0 {
+		t.Errorf("Impair(): interface recorded for change that was not made, got: %v", c.prior)
+	}
+}
+
+func TestRestoreAll(t *testing.T) {
+	ctx := context.Background()
+	ac := newFakeAite(t)
+	c := New(ac)
+	if _, err := c.OperDown(ctx, "eth0"); err != nil {
+		t.Fatalf("OperDown(): cannot change eth0, %v", err)
+	}
+	if _, err := c.AdminDown(ctx, "eth1"); err != nil {
+		t.Fatalf("AdminDown(): cannot change eth1, %v", err)
+	}
+
+	if err := c.RestoreAll(ctx); err != nil {
+		t.Fatalf("RestoreAll(): cannot restore interfaces, %v", err)
+	}
+	for _, name := range []string{"eth0", "eth1"} {
+		wantParams(t, ac, name, &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.prior) != 0 {
+		t.Errorf("RestoreAll(): interfaces not restored, got: %v", c.prior)
+	}
+}
+
+func TestNewForTest(t *testing.T) {
+	ac := newFakeAite(t)
+	t.Run("impair", func(t *testing.T) {
+		c := NewForTest(t, ac)
+		if _, err := c.AdminDown(context.Background(), "eth0"); err != nil {
+			t.Fatalf("AdminDown(): cannot change eth0, %v", err)
+		}
+		if _, err := c.Impair(context.Background(), "eth1", WithLatency(50*time.Millisecond)); err != nil {
+			t.Fatalf("Impair(): cannot change eth1, %v", err)
+		}
+		wantParams(t, ac, "eth0", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN})
+	})
+
+	// Each interface that the client changed is restored once the test within
+	// which it was used completes.
+	for _, name := range []string{"eth0", "eth1"} {
+		wantParams(t, ac, name, &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+	}
+}
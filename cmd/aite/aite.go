@@ -26,6 +26,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/openconfig/aite/scenario"
 	"github.com/openconfig/aite/srv"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -35,15 +36,26 @@ import (
 )
 
 var (
-	port = flag.Uint("port", 60061, "port for the aite service to listen on")
+	port     = flag.Uint("port", 60061, "port for the aite service to listen on")
+	profiles = flag.String("profiles", "", "path to a YAML or JSON file of named impairment profiles that can be applied to interfaces")
 )
 
 func main() {
 	klog.InitFlags(nil)
 	flag.Parse()
 
+	opts := []srv.Option{}
+	if *profiles != "" {
+		p, err := scenario.LoadProfiles(*profiles)
+		if err != nil {
+			klog.Exitf("cannot load profiles, %v", err)
+		}
+		klog.Infof("loaded %d profiles from %s", len(p), *profiles)
+		opts = append(opts, srv.WithProfiles(p))
+	}
+
 	serv := grpc.NewServer()
-	as, err := srv.New()
+	as, err := srv.New(opts...)
 	if err != nil {
 		klog.Exitf("cannot create Aite server, %v", err)
 	}
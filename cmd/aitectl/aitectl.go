@@ -0,0 +1,292 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary aitectl is a command-line client for the Aite service. Each command is
+// sent to every Aite instance in the list of targets concurrently, and the results
+// are rendered as tables, or as JSON with one object per line, for example:
+//
+//	aitectl -targets r1:60061,r2:60061 set -latency 50ms -loss 1.5 eth1
+//	aitectl -targets r1:60061 -json get eth1
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+var (
+	targets = flag.String("targets", "localhost:60061", "comma-separated list of addresses of the Aite instances to send commands to")
+	jsonOut = flag.Bool("json", false, "render results as JSON, with one object per line, rather than as tables")
+	timeout = flag.Duration("timeout", 30*time.Second, "timeout for commands that do not stream results")
+)
+
+// command is a subcommand of aitectl.
+type command struct {
+	// usage describes the arguments of the command.
+	usage string
+	// help is a one-line description of the command.
+	help string
+	// run runs the command against the specified targets with its arguments.
+	run func(ctx context.Context, ts []*target, args []string) error
+}
+
+// commands are the subcommands of aitectl, keyed by name.
+var commands = map[string]*command{
+	"list":     {usage: "", help: "list the interfaces of each target", run: runList},
+	"get":      {usage: "NAME", help: "get the state and impairments of an interface", run: runGet},
+	"set":      {usage: "[flags] NAME", help: "set the state and impairments of an interface", run: runSet},
+	"clear":    {usage: "NAME", help: "remove the impairments applied to an interface", run: runClear},
+	"flap":     {usage: "[flags] NAME", help: "repeatedly bring an interface down and back up", run: runFlap},
+	"stats":    {usage: "NAME", help: "get the counters and qdisc statistics of an interface", run: runStats},
+	"scenario": {usage: "run [flags] FILE", help: "run a scenario file against each target", run: runScenario},
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] COMMAND [args]\n\nCommands:\n", os.Args[0])
+	names := []string{}
+	for n := range commands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(out, "  %s\n    \t%s\n", strings.TrimSpace(n+" "+commands[n].usage), commands[n].help)
+	}
+	fmt.Fprintf(out, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %s\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	ts, err := dial(strings.Split(*targets, ","))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer func() {
+		for _, t := range ts {
+			t.conn.Close()
+		}
+	}()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if err := cmd.run(ctx, ts, flag.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// target is an Aite instance to which commands are sent.
+type target struct {
+	// addr is the address of the instance.
+	addr string
+	conn *grpc.ClientConn
+	c    apb.AiteClient
+}
+
+// dial returns a target for each of the specified addresses.
+func dial(addrs []string) ([]*target, error) {
+	ts := []*target{}
+	for _, a := range addrs {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+		conn, err := grpc.Dial(a, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot dial %s, %v", a, err)
+		}
+		ts = append(ts, &target{addr: a, conn: conn, c: apb.NewAiteClient(conn)})
+	}
+	if len(ts) == 0 {
+		return nil, fmt.Errorf("at least one target must be specified")
+	}
+	return ts, nil
+}
+
+// parse parses args with fs, permitting flags to follow positional arguments, and
+// returns the positional arguments. want is the number of positional arguments that
+// are required.
+func parse(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	pos := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+	if len(pos) != want {
+		return nil, fmt.Errorf("%s requires %d argument(s), got: %d", fs.Name(), want, len(pos))
+	}
+	return pos, nil
+}
+
+// result is the response of a target to a command.
+type result struct {
+	addr string
+	resp proto.Message
+	err  error
+}
+
+// invoke calls fn for each target concurrently, with a context bounded by the
+// timeout, returning their results in the order of the targets.
+func invoke(ctx context.Context, ts []*target, fn func(context.Context, *target) (proto.Message, error)) []*result {
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	results := make([]*result, len(ts))
+	var wg sync.WaitGroup
+	for i, t := range ts {
+		wg.Add(1)
+		go func(i int, t *target) {
+			defer wg.Done()
+			resp, err := fn(ctx, t)
+			results[i] = &result{addr: t.addr, resp: resp, err: err}
+		}(i, t)
+	}
+	wg.Wait()
+	return results
+}
+
+// render writes the successful results to stdout, either as JSON or using table,
+// which writes a table of the results to a tabwriter. Failures are written to
+// stderr, and an error is returned if any target failed.
+func render(results []*result, table func(w io.Writer, rs []*result)) error {
+	ok := []*result{}
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", r.addr, r.err)
+			failed++
+			continue
+		}
+		ok = append(ok, r)
+	}
+
+	if *jsonOut {
+		for _, r := range ok {
+			if err := printJSON(r.addr, r.resp); err != nil {
+				return err
+			}
+		}
+	} else if len(ok) != 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		table(w, ok)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d targets failed", failed, len(results))
+	}
+	return nil
+}
+
+// outMu serialises writes of streamed results to stdout.
+var outMu sync.Mutex
+
+// printJSON writes the response m from the target with the specified address to
+// stdout as a single line of JSON.
+func printJSON(addr string, m proto.Message) error {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("cannot marshal response from %s, %v", addr, err)
+	}
+	line, err := json.Marshal(struct {
+		Target   string          `json:"target"`
+		Response json.RawMessage `json:"response"`
+	}{addr, b})
+	if err != nil {
+		return fmt.Errorf("cannot marshal response from %s, %v", addr, err)
+	}
+
+	outMu.Lock()
+	defer outMu.Unlock()
+	fmt.Println(string(line))
+	return nil
+}
+
+// stream calls fn for each target concurrently, where fn receives responses from a
+// stream and calls emit for each, which writes it to stdout either as JSON or as
+// the line returned by format. Failures are written to stderr, and an error is
+// returned if any target failed.
+func stream(ctx context.Context, ts []*target, fn func(ctx context.Context, t *target, emit func(proto.Message) error) error, format func(addr string, m proto.Message) string) error {
+	errs := make([]error, len(ts))
+	var wg sync.WaitGroup
+	for i, t := range ts {
+		wg.Add(1)
+		go func(i int, t *target) {
+			defer wg.Done()
+			errs[i] = fn(ctx, t, func(m proto.Message) error {
+				if *jsonOut {
+					return printJSON(t.addr, m)
+				}
+				outMu.Lock()
+				defer outMu.Unlock()
+				fmt.Println(format(t.addr, m))
+				return nil
+			})
+		}(i, t)
+	}
+	wg.Wait()
+
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", ts[i].addr, err)
+			failed++
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d of %d targets failed", failed, len(ts))
+	}
+	return nil
+}
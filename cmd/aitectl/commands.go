@@ -0,0 +1,361 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openconfig/aite/client"
+	"github.com/openconfig/aite/scenario"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// runList implements the list command.
+func runList(ctx context.Context, ts []*target, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	results := invoke(ctx, ts, func(ctx context.Context, t *target) (proto.Message, error) {
+		return t.c.ListInterfaces(ctx, &apb.ListInterfacesRequest{})
+	})
+	return render(results, func(w io.Writer, rs []*result) {
+		fmt.Fprintln(w, "TARGET\tNAME\tINDEX\tADMIN\tOPER\tMTU\tQDISC")
+		for _, r := range rs {
+			for _, i := range r.resp.(*apb.ListInterfacesResponse).GetInterfaces() {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%d\t%s\n", r.addr, i.Name, i.Index, i.AdminState, i.OperState, i.Mtu, qdiscString(i.RootQdisc))
+			}
+		}
+	})
+}
+
+// runGet implements the get command.
+func runGet(ctx context.Context, ts []*target, args []string) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	results := invoke(ctx, ts, func(ctx context.Context, t *target) (proto.Message, error) {
+		return t.c.GetInterface(ctx, &apb.GetInterfaceRequest{Name: pos[0]})
+	})
+	return render(results, func(w io.Writer, rs []*result) {
+		fmt.Fprintln(w, "TARGET\tNAME\tSTATE\tDIRECTION\tLATENCY\tJITTER\tLOSS\tRATE\tFLOWS")
+		for _, r := range rs {
+			resp := r.resp.(*apb.GetInterfaceResponse)
+			p := resp.GetParams()
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n", r.addr, resp.GetInterface().GetName(), p.GetState(), p.GetDirection(),
+				msecString(p.GetLatencyMsec()), msecString(p.GetJitterMsec()), lossString(p), rateString(p.GetRateBps()), len(resp.GetFlows()))
+		}
+	})
+}
+
+// runSet implements the set command.
+func runSet(ctx context.Context, ts []*target, args []string) error {
+	fs := flag.NewFlagSet("set", flag.ContinueOnError)
+	state := fs.String("state", "", "state of the interface, one of up, admin-down or oper-down; defaults to up unless a profile is specified")
+	profile := fs.String("profile", "", "name of a profile loaded by Aite to apply to the interface, which the other flags override")
+	params := fs.String("params", "", "InterfaceStateParams encoded as protojson, which the other flags override")
+	latency := fs.Duration("latency", 0, "latency added to each packet")
+	jitter := fs.Duration("jitter", 0, "jitter added to the latency of each packet")
+	loss := fs.Float64("loss", 0, "percentage of packets to drop, up to 100 to a precision of one part per million, e.g., 1.5")
+	duplicate := fs.Uint("duplicate", 0, "percentage of packets to duplicate")
+	corrupt := fs.Uint("corrupt", 0, "percentage of packets to corrupt")
+	reorder := fs.Uint("reorder", 0, "percentage of packets to send immediately, reordering them with respect to others")
+	rate := fs.Uint64("rate", 0, "rate to which the interface is limited in bits per second")
+	direction := fs.String("direction", "", "direction of traffic to impair, one of egress, ingress or both")
+	duration := fs.Duration("duration", 0, "time after which the interface is restored to its prior state")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	p := &apb.InterfaceStateParams{}
+	if *params != "" {
+		if err := protojson.Unmarshal([]byte(*params), p); err != nil {
+			return fmt.Errorf("invalid params, %v", err)
+		}
+	}
+	switch {
+	case *state == "" && (*profile != "" || *params != ""):
+	case *state == "", *state == "up":
+		p.State = apb.InterfaceState_IS_UP
+	case *state == "admin-down":
+		p.State = apb.InterfaceState_IS_ADMIN_DOWN
+	case *state == "oper-down":
+		p.State = apb.InterfaceState_IS_OPER_DOWN
+	default:
+		return fmt.Errorf("invalid state %s", *state)
+	}
+	switch *direction {
+	case "":
+	case "egress":
+		p.Direction = apb.Direction_DIR_EGRESS
+	case "ingress":
+		p.Direction = apb.Direction_DIR_INGRESS
+	case "both":
+		p.Direction = apb.Direction_DIR_BOTH
+	default:
+		return fmt.Errorf("invalid direction %s", *direction)
+	}
+	if *latency != 0 {
+		p.LatencyMsec = uint32(*latency / time.Millisecond)
+	}
+	if *jitter != 0 {
+		p.JitterMsec = uint32(*jitter / time.Millisecond)
+	}
+	if *loss != 0 {
+		pct, ppm, err := client.LossParams(*loss)
+		if err != nil {
+			return err
+		}
+		p.LossPct, p.LossPpm = pct, ppm
+	}
+	if *duplicate != 0 {
+		p.DuplicatePct = uint32(*duplicate)
+	}
+	if *corrupt != 0 {
+		p.CorruptPct = uint32(*corrupt)
+	}
+	if *reorder != 0 {
+		p.ReorderPct = uint32(*reorder)
+	}
+	if *rate != 0 {
+		p.RateBps = *rate
+	}
+
+	req := &apb.SetInterfaceRequest{
+		Name:         pos[0],
+		Params:       p,
+		ProfileName:  *profile,
+		DurationMsec: uint32(*duration / time.Millisecond),
+	}
+	results := invoke(ctx, ts, func(ctx context.Context, t *target) (proto.Message, error) {
+		return t.c.SetInterface(ctx, req)
+	})
+	return render(results, func(w io.Writer, rs []*result) {
+		fmt.Fprintln(w, "TARGET\tNAME\tSTATE\tDIRECTION\tLATENCY\tJITTER\tLOSS\tRATE\tCHANGED")
+		for _, r := range rs {
+			resp := r.resp.(*apb.SetInterfaceResponse)
+			p := resp.GetParams()
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%t\n", r.addr, resp.GetName(), p.GetState(), p.GetDirection(),
+				msecString(p.GetLatencyMsec()), msecString(p.GetJitterMsec()), lossString(p), rateString(p.GetRateBps()), resp.GetChanged())
+		}
+	})
+}
+
+// runClear implements the clear command.
+func runClear(ctx context.Context, ts []*target, args []string) error {
+	fs := flag.NewFlagSet("clear", flag.ContinueOnError)
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	results := invoke(ctx, ts, func(ctx context.Context, t *target) (proto.Message, error) {
+		return t.c.ClearInterface(ctx, &apb.ClearInterfaceRequest{Name: pos[0]})
+	})
+	return render(results, func(w io.Writer, rs []*result) {
+		fmt.Fprintln(w, "TARGET\tNAME\tADMIN\tOPER\tQDISC")
+		for _, r := range rs {
+			i := r.resp.(*apb.ClearInterfaceResponse).GetInterface()
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.addr, i.GetName(), i.GetAdminState(), i.GetOperState(), qdiscString(i.GetRootQdisc()))
+		}
+	})
+}
+
+// runFlap implements the flap command.
+func runFlap(ctx context.Context, ts []*target, args []string) error {
+	fs := flag.NewFlagSet("flap", flag.ContinueOnError)
+	down := fs.Duration("down", time.Second, "time for which the interface is held down in each flap")
+	up := fs.Duration("up", time.Second, "time for which the interface is held up between flaps")
+	count := fs.Uint("count", 1, "number of flaps, where 0 flaps the interface until interrupted")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	req := &apb.FlapInterfaceRequest{
+		Name:     pos[0],
+		DownMsec: uint32(*down / time.Millisecond),
+		UpMsec:   uint32(*up / time.Millisecond),
+		Count:    uint32(*count),
+	}
+	return stream(ctx, ts, func(ctx context.Context, t *target, emit func(proto.Message) error) error {
+		s, err := t.c.FlapInterface(ctx, req)
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := s.Recv()
+			switch {
+			case errors.Is(err, io.EOF):
+				return nil
+			case err != nil:
+				return err
+			}
+			if err := emit(resp); err != nil {
+				return err
+			}
+		}
+	}, func(addr string, m proto.Message) string {
+		resp := m.(*apb.FlapInterfaceResponse)
+		return fmt.Sprintf("%s %s %s flap %d %s", timeString(resp.Timestamp), addr, resp.Name, resp.Iteration, resp.State)
+	})
+}
+
+// runStats implements the stats command.
+func runStats(ctx context.Context, ts []*target, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	results := invoke(ctx, ts, func(ctx context.Context, t *target) (proto.Message, error) {
+		return t.c.GetInterfaceStats(ctx, &apb.GetInterfaceStatsRequest{Name: pos[0]})
+	})
+	return render(results, func(w io.Writer, rs []*result) {
+		fmt.Fprintln(w, "TARGET\tNAME\tRX_PACKETS\tTX_PACKETS\tRX_BYTES\tTX_BYTES\tRX_DROPPED\tTX_DROPPED\tRX_ERRORS\tTX_ERRORS")
+		for _, r := range rs {
+			resp := r.resp.(*apb.GetInterfaceStatsResponse)
+			c := resp.GetCounters()
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", r.addr, resp.GetInterface().GetName(),
+				c.GetRxPackets(), c.GetTxPackets(), c.GetRxBytes(), c.GetTxBytes(), c.GetRxDropped(), c.GetTxDropped(), c.GetRxErrors(), c.GetTxErrors())
+		}
+		// A line without cells ends the alignment of the columns of the table above.
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "TARGET\tDEVICE\tQDISC\tPARENT\tPACKETS\tBYTES\tDROPS\tOVERLIMITS\tBACKLOG\tQLEN")
+		for _, r := range rs {
+			for _, q := range r.resp.(*apb.GetInterfaceStatsResponse).GetQdiscs() {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n", r.addr, q.Device, qdiscString(q.Qdisc), q.GetQdisc().GetParent(),
+					q.Packets, q.Bytes, q.Drops, q.Overlimits, q.BacklogBytes, q.Qlen)
+			}
+		}
+	})
+}
+
+// runScenario implements the scenario command.
+func runScenario(ctx context.Context, ts []*target, args []string) error {
+	if len(args) == 0 || args[0] != "run" {
+		return fmt.Errorf("scenario requires the run subcommand")
+	}
+	fs := flag.NewFlagSet("scenario run", flag.ContinueOnError)
+	restore := fs.Bool("restore", false, "restore the interfaces changed by the scenario once it ends, overriding the scenario file")
+	pos, err := parse(fs, args[1:], 1)
+	if err != nil {
+		return err
+	}
+
+	req, err := scenario.Load(pos[0])
+	if err != nil {
+		return err
+	}
+	if *restore {
+		req.Restore = true
+	}
+
+	// The scenario is validated against the interfaces of every target before it
+	// is run against any, such that it is not partially run.
+	results := invoke(ctx, ts, func(ctx context.Context, t *target) (proto.Message, error) {
+		resp, err := t.c.ListInterfaces(ctx, &apb.ListInterfacesRequest{})
+		if err != nil {
+			return nil, err
+		}
+		names := []string{}
+		for _, i := range resp.GetInterfaces() {
+			names = append(names, i.GetName())
+		}
+		if err := scenario.Validate(req, names); err != nil {
+			return nil, fmt.Errorf("invalid scenario, %v", err)
+		}
+		return resp, nil
+	})
+	for _, r := range results {
+		if r.err != nil {
+			return fmt.Errorf("%s: %v", r.addr, r.err)
+		}
+	}
+
+	return stream(ctx, ts, func(ctx context.Context, t *target, emit func(proto.Message) error) error {
+		s, err := t.c.RunScenario(ctx, req)
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := s.Recv()
+			switch {
+			case errors.Is(err, io.EOF):
+				return nil
+			case err != nil:
+				return err
+			}
+			if err := emit(resp); err != nil {
+				return err
+			}
+		}
+	}, func(addr string, m proto.Message) string {
+		resp := m.(*apb.RunScenarioResponse)
+		lag := time.Duration(resp.Timestamp - resp.ScheduledTimestamp)
+		return fmt.Sprintf("%s %s step %d applied %s after schedule", timeString(resp.Timestamp), addr, resp.Step, lag)
+	})
+}
+
+// qdiscString returns the kind and handle of the qdisc q in the form used by tc.
+func qdiscString(q *apb.Qdisc) string {
+	if q == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s %s", q.Kind, q.Handle)
+}
+
+// msecString returns the duration of the specified number of milliseconds.
+func msecString(msec uint32) string {
+	return (time.Duration(msec) * time.Millisecond).String()
+}
+
+// lossString returns the loss applied by p as a percentage.
+func lossString(p *apb.InterfaceStateParams) string {
+	if p.GetLossPpm() != 0 {
+		return fmt.Sprintf("%g%%", float64(p.GetLossPpm())/1e4)
+	}
+	return fmt.Sprintf("%d%%", p.GetLossPct())
+}
+
+// rateString returns the rate limit of the specified number of bits per second,
+// or - if the rate is not limited.
+func rateString(bps uint64) string {
+	if bps == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%dbps", bps)
+}
+
+// timeString returns the time of the specified number of nanoseconds since the
+// Unix epoch.
+func timeString(ns int64) string {
+	return time.Unix(0, ns).Format("15:04:05.000")
+}
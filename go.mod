@@ -5,11 +5,14 @@ go 1.20
 require (
 	github.com/florianl/go-tc v0.4.2
 	github.com/openconfig/magna v0.0.0-20231125035949-e9288e23d88d
+	github.com/vishvananda/netlink v1.3.0
+	github.com/vishvananda/netns v0.0.4
 	golang.org/x/sys v0.13.0
 	google.golang.org/grpc v1.59.0
 	google.golang.org/protobuf v1.31.0
 	k8s.io/klog v1.0.0
 	k8s.io/klog/v2 v2.110.1
+	sigs.k8s.io/yaml v1.4.0
 )
 
 require (
@@ -19,8 +22,6 @@ require (
 	github.com/josharian/native v1.1.0 // indirect
 	github.com/mdlayher/netlink v1.6.0 // indirect
 	github.com/mdlayher/socket v0.1.1 // indirect
-	github.com/vishvananda/netlink v1.1.1-0.20210330154013-f5de75959ad5 // indirect
-	github.com/vishvananda/netns v0.0.0-20200728191858-db3c7e526aae // indirect
 	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/sync v0.3.0 // indirect
 	golang.org/x/text v0.13.0 // indirect
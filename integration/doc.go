@@ -0,0 +1,25 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package integration contains tests that run an Aite server within throwaway
+// network namespaces, and verify using probes sent across a veth pair that the
+// impairments that it applies take effect in the kernel. The tests must be run as
+// root, and are skipped otherwise, for example:
+//
+//	go test -c -o /tmp/integration.test ./integration
+//	sudo /tmp/integration.test -test.v
+//
+// Tests that apply impairments are also skipped where the kernel does not support
+// netem qdiscs.
+package integration
@@ -0,0 +1,554 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"net"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv"
+	"github.com/openconfig/aite/srv/backend"
+)
+
+const (
+	// dutIntf is the end of the veth pair that Aite impairs.
+	dutIntf = "aite0"
+	// peerIntf is the end of the veth pair on which the echo server listens.
+	peerIntf = "aite1"
+	// echoPort is the UDP port of the echo server.
+	echoPort = 7
+)
+
+var (
+	dutAddr  = &net.IPNet{IP: net.IPv4(192, 0, 2, 1), Mask: net.CIDRMask(24, 32)}
+	peerAddr = &net.IPNet{IP: net.IPv4(192, 0, 2, 2), Mask: net.CIDRMask(24, 32)}
+)
+
+// topology is a pair of network namespaces connected by a veth pair. An Aite
+// server runs within the DUT namespace, and a UDP echo server within the peer.
+type topology struct {
+	// s is the Aite server, which manipulates the DUT namespace.
+	s *srv.S
+	// origin is the namespace within which the test was started.
+	origin netns.NsHandle
+	// dut and peer are the namespaces containing dutIntf and peerIntf.
+	dut, peer netns.NsHandle
+	// conn is the socket within the DUT namespace from which probes are sent.
+	conn *net.UDPConn
+}
+
+// newTopology creates a topology for the test t, which is removed when the test
+// completes. It skips the test if it is not run as root.
+//
+// Network namespaces are a property of a thread, and hence the test goroutine is
+// locked to its thread for the remainder of the test, such that the Aite server and
+// every socket are created within the intended namespace. The RPCs of the server
+// are called directly, rather than via gRPC, since gRPC handlers run on other
+// threads, and hence changes must not be scheduled to be reverted.
+func newTopology(t *testing.T) *topology {
+	t.Helper()
+	if os.Geteuid() != 0 {
+		t.Skip("network namespace tests must be run as root")
+	}
+
+	runtime.LockOSThread()
+	origin, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		t.Fatalf("cannot get network namespace, %v", err)
+	}
+	t.Cleanup(func() {
+		if err := netns.Set(origin); err != nil {
+			// The thread cannot be reused, so it is left locked such that
+			// it is terminated when the test goroutine exits.
+			t.Errorf("cannot return to original network namespace, %v", err)
+			return
+		}
+		origin.Close()
+		runtime.UnlockOSThread()
+	})
+
+	// The peer namespace is created first, such that the thread is left within
+	// the DUT namespace.
+	peer, err := netns.New()
+	if err != nil {
+		t.Skipf("cannot create network namespace, %v", err)
+	}
+	t.Cleanup(func() { peer.Close() })
+	dut, err := netns.New()
+	if err != nil {
+		t.Fatalf("cannot create network namespace, %v", err)
+	}
+	t.Cleanup(func() { dut.Close() })
+	tp := &topology{origin: origin, dut: dut, peer: peer}
+
+	if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: dutIntf}, PeerName: peerIntf}); err != nil {
+		t.Fatalf("cannot create veth pair, %v", err)
+	}
+	p, err := netlink.LinkByName(peerIntf)
+	if err != nil {
+		t.Fatalf("cannot find %s, %v", peerIntf, err)
+	}
+	if err := netlink.LinkSetNsFd(p, int(peer)); err != nil {
+		t.Fatalf("cannot move %s to peer namespace, %v", peerIntf, err)
+	}
+	configure(t, dutIntf, dutAddr)
+	tp.in(t, peer, func() {
+		configure(t, peerIntf, peerAddr)
+		startEcho(t)
+	})
+	// Traffic sent before the link is operationally up is discarded, and hence
+	// the first probes sent would otherwise be lost.
+	waitOperUp(t, dutIntf)
+
+	s, err := srv.New()
+	if err != nil {
+		t.Fatalf("cannot create Aite server, %v", err)
+	}
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Errorf("cannot stop Aite server, %v", err)
+		}
+	})
+	tp.s = s
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: dutAddr.IP})
+	if err != nil {
+		t.Fatalf("cannot open probe socket, %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	tp.conn = conn
+
+	return tp
+}
+
+// in calls fn within the namespace ns, returning to the DUT namespace afterwards.
+func (tp *topology) in(t *testing.T, ns netns.NsHandle, fn func()) {
+	t.Helper()
+	if err := netns.Set(ns); err != nil {
+		t.Fatalf("cannot enter network namespace, %v", err)
+	}
+	defer func() {
+		if err := netns.Set(tp.dut); err != nil {
+			t.Fatalf("cannot return to DUT network namespace, %v", err)
+		}
+	}()
+	fn()
+}
+
+// run runs fn as a subtest of t with the specified name. Subtests run on their own
+// goroutine, which is locked to its thread and enters the DUT namespace for the
+// duration of the subtest.
+func (tp *topology) run(t *testing.T, name string, fn func(t *testing.T)) {
+	t.Run(name, func(t *testing.T) {
+		runtime.LockOSThread()
+		if err := netns.Set(tp.dut); err != nil {
+			t.Fatalf("cannot enter DUT network namespace, %v", err)
+		}
+		defer func() {
+			if err := netns.Set(tp.origin); err != nil {
+				t.Errorf("cannot return to original network namespace, %v", err)
+				return
+			}
+			runtime.UnlockOSThread()
+		}()
+		fn(t)
+	})
+}
+
+// configure assigns addr to the link with the specified name, and brings it up.
+func configure(t *testing.T, name string, addr *net.IPNet) {
+	t.Helper()
+	l, err := netlink.LinkByName(name)
+	if err != nil {
+		t.Fatalf("cannot find %s, %v", name, err)
+	}
+	if err := netlink.AddrAdd(l, &netlink.Addr{IPNet: addr}); err != nil {
+		t.Fatalf("cannot add address to %s, %v", name, err)
+	}
+	if err := netlink.LinkSetUp(l); err != nil {
+		t.Fatalf("cannot bring up %s, %v", name, err)
+	}
+}
+
+// waitOperUp waits for the link with the specified name to be operationally up.
+func waitOperUp(t *testing.T, name string) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		l, err := netlink.LinkByName(name)
+		if err != nil {
+			t.Fatalf("cannot find %s, %v", name, err)
+		}
+		if l.Attrs().OperState == netlink.OperUp {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s not operationally up, got state: %s", name, l.Attrs().OperState)
+		}
+	}
+}
+
+// startEcho starts a UDP echo server within the current namespace, which is stopped
+// when the test completes.
+func startEcho(t *testing.T) {
+	t.Helper()
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: peerAddr.IP, Port: echoPort})
+	if err != nil {
+		t.Fatalf("cannot open echo socket, %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, from, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			conn.WriteToUDP(buf[:n], from)
+		}
+	}()
+}
+
+// requireNetem skips the test t if the kernel does not support netem qdiscs.
+func (tp *topology) requireNetem(t *testing.T) {
+	t.Helper()
+	b, err := backend.NewNetlink()
+	if err != nil {
+		t.Fatalf("cannot create netlink backend, %v", err)
+	}
+	defer b.Close()
+
+	l, err := b.LinkByName(dutIntf)
+	if err != nil {
+		t.Fatalf("cannot find %s, %v", dutIntf, err)
+	}
+	opts := &bytes.Buffer{}
+	if err := binary.Write(opts, nl.NativeEndian(), tc.NetemQopt{Limit: 1000}); err != nil {
+		t.Fatalf("cannot encode netem options, %v", err)
+	}
+	msg := tc.Msg{
+		Family:  unix.AF_UNSPEC,
+		Ifindex: uint32(l.Index),
+		Handle:  core.BuildHandle(0x1, 0x0),
+		Parent:  tc.HandleRoot,
+	}
+	if err := b.QdiscReplace(msg, "netem", opts.Bytes()); err != nil {
+		t.Skipf("kernel does not support netem qdiscs, %v", err)
+	}
+	if err := b.QdiscDel(msg); err != nil {
+		t.Fatalf("cannot remove netem qdisc, %v", err)
+	}
+}
+
+// requireIfb skips the test t if the kernel does not support ifb devices, which
+// are required to impair ingress traffic.
+func (tp *topology) requireIfb(t *testing.T) {
+	t.Helper()
+	b, err := backend.NewNetlink()
+	if err != nil {
+		t.Fatalf("cannot create netlink backend, %v", err)
+	}
+	defer b.Close()
+
+	const name = "aiteprobe"
+	if err := b.LinkAddIfb(name); err != nil {
+		t.Skipf("kernel does not support ifb devices, %v", err)
+	}
+	if err := b.LinkDel(name); err != nil {
+		t.Fatalf("cannot remove ifb device, %v", err)
+	}
+}
+
+// set calls the SetInterface RPC of the Aite server for dutIntf.
+func (tp *topology) set(t *testing.T, params *apb.InterfaceStateParams) {
+	t.Helper()
+	if _, err := tp.s.SetInterface(context.Background(), &apb.SetInterfaceRequest{Name: dutIntf, Params: params}); err != nil {
+		t.Fatalf("cannot set interface %s, %v", dutIntf, err)
+	}
+}
+
+// clear calls the ClearInterface RPC of the Aite server for dutIntf.
+func (tp *topology) clear(t *testing.T) {
+	t.Helper()
+	if _, err := tp.s.ClearInterface(context.Background(), &apb.ClearInterfaceRequest{Name: dutIntf}); err != nil {
+		t.Fatalf("cannot clear interface %s, %v", dutIntf, err)
+	}
+}
+
+// probeInterval is the interval at which probes are sent.
+const probeInterval = 2 * time.Millisecond
+
+// probes is the result of sending a series of probes to the echo server.
+type probes struct {
+	// sent is the number of probes that were sent.
+	sent int
+	// rtts is the round-trip time of each probe that was echoed, in ascending
+	// order.
+	rtts []time.Duration
+}
+
+// received returns the fraction of the probes that were echoed.
+func (p *probes) received() float64 {
+	return float64(len(p.rtts)) / float64(p.sent)
+}
+
+// median returns the median round-trip time of the probes that were echoed.
+func (p *probes) median() time.Duration {
+	if len(p.rtts) == 0 {
+		return 0
+	}
+	return p.rtts[len(p.rtts)/2]
+}
+
+// probe sends n probes to the echo server, returning those that were echoed within
+// wait of the final probe being sent. Probes are sent at a fixed interval rather than
+// each awaiting the echo of the last, such that lost probes do not delay the test.
+// Probes that cannot be sent, such as when the interface is down, are lost.
+func (tp *topology) probe(t *testing.T, n int, wait time.Duration) *probes {
+	t.Helper()
+	// Each series of probes is identified by a random ID, such that echoes of
+	// probes delayed from an earlier series are discarded.
+	id := rand.Uint64()
+	echo := &net.UDPAddr{IP: peerAddr.IP, Port: echoPort}
+
+	var mu sync.Mutex
+	sent := make([]time.Time, n)
+	echoed := map[uint64]time.Time{}
+	if err := tp.conn.SetReadDeadline(time.Time{}); err != nil {
+		t.Fatalf("cannot clear read deadline, %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 64)
+		for {
+			l, err := tp.conn.Read(buf)
+			if err != nil {
+				return
+			}
+			if l != 16 || binary.BigEndian.Uint64(buf[0:8]) != id {
+				continue
+			}
+			mu.Lock()
+			echoed[binary.BigEndian.Uint64(buf[8:16])] = time.Now()
+			mu.Unlock()
+		}
+	}()
+
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf[0:8], id)
+	for i := 0; i < n; i++ {
+		binary.BigEndian.PutUint64(buf[8:16], uint64(i))
+		mu.Lock()
+		sent[i] = time.Now()
+		mu.Unlock()
+		tp.conn.WriteToUDP(buf, echo)
+		time.Sleep(probeInterval)
+	}
+
+	if err := tp.conn.SetReadDeadline(time.Now().Add(wait)); err != nil {
+		t.Fatalf("cannot set read deadline, %v", err)
+	}
+	<-done
+
+	p := &probes{sent: n}
+	for seq, at := range echoed {
+		if seq < uint64(n) {
+			p.rtts = append(p.rtts, at.Sub(sent[seq]))
+		}
+	}
+	sort.Slice(p.rtts, func(i, j int) bool { return p.rtts[i] < p.rtts[j] })
+	return p
+}
+
+func TestBaseline(t *testing.T) {
+	tp := newTopology(t)
+
+	p := tp.probe(t, 50, time.Second)
+	if got := p.received(); got != 1 {
+		t.Errorf("received fraction of probes without impairment, got: %.2f, want: 1", got)
+	}
+	if got, max := p.median(), 20*time.Millisecond; got > max {
+		t.Errorf("median round-trip time without impairment, got: %s, want: <= %s", got, max)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	tp := newTopology(t)
+	tp.requireNetem(t)
+
+	// The echo server is not impaired, and hence latency applied in both
+	// directions of the DUT interface is incurred twice by each probe.
+	tests := []struct {
+		desc      string
+		latency   uint32
+		direction apb.Direction
+		want      time.Duration
+	}{{
+		desc:      "egress",
+		latency:   50,
+		direction: apb.Direction_DIR_EGRESS,
+		want:      50 * time.Millisecond,
+	}, {
+		desc:      "ingress",
+		latency:   50,
+		direction: apb.Direction_DIR_INGRESS,
+		want:      50 * time.Millisecond,
+	}, {
+		desc:      "both",
+		latency:   40,
+		direction: apb.Direction_DIR_BOTH,
+		want:      80 * time.Millisecond,
+	}, {
+		desc:      "long",
+		latency:   300,
+		direction: apb.Direction_DIR_EGRESS,
+		want:      300 * time.Millisecond,
+	}}
+
+	for _, tt := range tests {
+		tp.run(t, tt.desc, func(t *testing.T) {
+			if tt.direction != apb.Direction_DIR_EGRESS {
+				tp.requireIfb(t)
+			}
+			tp.set(t, &apb.InterfaceStateParams{
+				State:       apb.InterfaceState_IS_UP,
+				LatencyMsec: tt.latency,
+				Direction:   tt.direction,
+			})
+			defer tp.clear(t)
+
+			p := tp.probe(t, 50, tt.want+time.Second)
+			if got := p.received(); got != 1 {
+				t.Errorf("received fraction of probes, got: %.2f, want: 1", got)
+			}
+			// An incorrect conversion of latency to ticks scales it, and hence
+			// the round-trip time is bounded on both sides.
+			if len(p.rtts) != 0 && p.rtts[0] < tt.want {
+				t.Errorf("minimum round-trip time, got: %s, want: >= %s", p.rtts[0], tt.want)
+			}
+			if got, max := p.median(), tt.want+30*time.Millisecond; got > max {
+				t.Errorf("median round-trip time, got: %s, want: <= %s", got, max)
+			}
+		})
+	}
+}
+
+func TestLoss(t *testing.T) {
+	tp := newTopology(t)
+	tp.requireNetem(t)
+
+	// Loss is random, and hence the fraction of probes received is checked to be
+	// within a margin that is many standard deviations wide for the number of
+	// probes sent.
+	const (
+		n      = 500
+		margin = 0.1
+	)
+	tests := []struct {
+		desc   string
+		params *apb.InterfaceStateParams
+		want   float64
+	}{{
+		desc:   "percent",
+		params: &apb.InterfaceStateParams{LossPct: 50},
+		want:   0.5,
+	}, {
+		desc:   "ppm",
+		params: &apb.InterfaceStateParams{LossPpm: 250000},
+		want:   0.75,
+	}, {
+		desc:   "ingress",
+		params: &apb.InterfaceStateParams{LossPct: 30, Direction: apb.Direction_DIR_INGRESS},
+		want:   0.7,
+	}, {
+		desc:   "all",
+		params: &apb.InterfaceStateParams{LossPct: 100},
+		want:   0,
+	}}
+
+	for _, tt := range tests {
+		tp.run(t, tt.desc, func(t *testing.T) {
+			if tt.params.Direction != apb.Direction_DIR_EGRESS {
+				tp.requireIfb(t)
+			}
+			tt.params.State = apb.InterfaceState_IS_UP
+			tp.set(t, tt.params)
+			defer tp.clear(t)
+
+			p := tp.probe(t, n, time.Second)
+			if got := p.received(); got < tt.want-margin || got > tt.want+margin {
+				t.Errorf("received fraction of probes, got: %.2f, want: %.2f ± %.2f", got, tt.want, margin)
+			}
+		})
+	}
+}
+
+func TestAdminDown(t *testing.T) {
+	tp := newTopology(t)
+
+	tp.set(t, &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN})
+	resp, err := tp.s.GetInterface(context.Background(), &apb.GetInterfaceRequest{Name: dutIntf})
+	if err != nil {
+		t.Fatalf("cannot get interface %s, %v", dutIntf, err)
+	}
+	if got, want := resp.GetParams().GetState(), apb.InterfaceState_IS_ADMIN_DOWN; got != want {
+		t.Errorf("state of interface while down, got: %s, want: %s", got, want)
+	}
+	if got := tp.probe(t, 20, time.Second).received(); got != 0 {
+		t.Errorf("received fraction of probes while down, got: %.2f, want: 0", got)
+	}
+
+	tp.set(t, &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+	tp.clear(t)
+	if got := tp.probe(t, 20, time.Second).received(); got != 1 {
+		t.Errorf("received fraction of probes once up, got: %.2f, want: 1", got)
+	}
+}
+
+func TestIsolation(t *testing.T) {
+	tp := newTopology(t)
+
+	// The peer interface is in another namespace, and hence cannot be changed by
+	// the Aite server.
+	if _, err := tp.s.SetInterface(context.Background(), &apb.SetInterfaceRequest{
+		Name:   peerIntf,
+		Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN},
+	}); err == nil {
+		t.Errorf("set interface %s in peer namespace, got: nil error, want: error", peerIntf)
+	}
+	if got := tp.probe(t, 20, time.Second).received(); got != 1 {
+		t.Errorf("received fraction of probes, got: %.2f, want: 1", got)
+	}
+}
@@ -7,7 +7,8 @@
 // Package aite implements a disruptive service which can manipulate resources
 // within a KNE pod.
 //
-// It is named after Aite (Até) the Greek goddess of mischief, delusion and ruin.
+// It is named after Aite (Até) the Greek goddess of mischief, delusion
+// and ruin.
 
 package aite
 
@@ -35,6 +36,12 @@ const (
 	InterfaceState_IS_UP InterfaceState = 1
 	// The interface should be administratively down.
 	InterfaceState_IS_ADMIN_DOWN InterfaceState = 2
+	// The interface should be administratively up, but operationally down,
+	// emulating a loss of carrier (e.g., loss of light). Where the interface
+	// does not support its carrier being set, such as a veth, all packets
+	// sent and received by the interface are dropped whilst in this state,
+	// regardless of the direction specified in its params.
+	InterfaceState_IS_OPER_DOWN InterfaceState = 3
 )
 
 // Enum value maps for InterfaceState.
@@ -43,11 +50,13 @@ var (
 		0: "IS_UNSPECIFIED",
 		1: "IS_UP",
 		2: "IS_ADMIN_DOWN",
+		3: "IS_OPER_DOWN",
 	}
 	InterfaceState_value = map[string]int32{
 		"IS_UNSPECIFIED": 0,
 		"IS_UP":          1,
 		"IS_ADMIN_DOWN":  2,
+		"IS_OPER_DOWN":   3,
 	}
 )
 
@@ -78,6 +87,317 @@ func (InterfaceState) EnumDescriptor() ([]byte, []int) {
 	return file_aite_proto_rawDescGZIP(), []int{0}
 }
 
+// Direction specifies the traffic traversing an interface that is impaired.
+type Direction int32
+
+const (
+	// Packets transmitted by the interface are impaired.
+	Direction_DIR_EGRESS Direction = 0
+	// Packets received by the interface are impaired. Received packets are
+	// redirected to an intermediate functional block (ifb) device to which the
+	// impairments are applied.
+	Direction_DIR_INGRESS Direction = 1
+	// Packets transmitted and received by the interface are impaired, with the
+	// same impairments being applied in each direction.
+	Direction_DIR_BOTH Direction = 2
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DIR_EGRESS",
+		1: "DIR_INGRESS",
+		2: "DIR_BOTH",
+	}
+	Direction_value = map[string]int32{
+		"DIR_EGRESS":  0,
+		"DIR_INGRESS": 1,
+		"DIR_BOTH":    2,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_aite_proto_enumTypes[1].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_aite_proto_enumTypes[1]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{1}
+}
+
+// DelayDistribution specifies the distribution from which the jitter added to
+// the latency of a packet is drawn.
+type DelayDistribution int32
+
+const (
+	// Jitter is drawn from a uniform distribution.
+	DelayDistribution_DD_UNIFORM DelayDistribution = 0
+	// Jitter is drawn from a normal distribution.
+	DelayDistribution_DD_NORMAL DelayDistribution = 1
+	// Jitter is drawn from a pareto distribution.
+	DelayDistribution_DD_PARETO DelayDistribution = 2
+	// Jitter is drawn from a combination of the normal and pareto
+	// distributions.
+	DelayDistribution_DD_PARETONORMAL DelayDistribution = 3
+)
+
+// Enum value maps for DelayDistribution.
+var (
+	DelayDistribution_name = map[int32]string{
+		0: "DD_UNIFORM",
+		1: "DD_NORMAL",
+		2: "DD_PARETO",
+		3: "DD_PARETONORMAL",
+	}
+	DelayDistribution_value = map[string]int32{
+		"DD_UNIFORM":      0,
+		"DD_NORMAL":       1,
+		"DD_PARETO":       2,
+		"DD_PARETONORMAL": 3,
+	}
+)
+
+func (x DelayDistribution) Enum() *DelayDistribution {
+	p := new(DelayDistribution)
+	*p = x
+	return p
+}
+
+func (x DelayDistribution) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DelayDistribution) Descriptor() protoreflect.EnumDescriptor {
+	return file_aite_proto_enumTypes[2].Descriptor()
+}
+
+func (DelayDistribution) Type() protoreflect.EnumType {
+	return &file_aite_proto_enumTypes[2]
+}
+
+func (x DelayDistribution) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DelayDistribution.Descriptor instead.
+func (DelayDistribution) EnumDescriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{2}
+}
+
+// AdminState is the administrative state of an interface.
+type AdminState int32
+
+const (
+	// Invalid zero value.
+	AdminState_AS_UNSPECIFIED AdminState = 0
+	// The interface is administratively enabled.
+	AdminState_AS_UP AdminState = 1
+	// The interface is administratively disabled.
+	AdminState_AS_DOWN AdminState = 2
+)
+
+// Enum value maps for AdminState.
+var (
+	AdminState_name = map[int32]string{
+		0: "AS_UNSPECIFIED",
+		1: "AS_UP",
+		2: "AS_DOWN",
+	}
+	AdminState_value = map[string]int32{
+		"AS_UNSPECIFIED": 0,
+		"AS_UP":          1,
+		"AS_DOWN":        2,
+	}
+)
+
+func (x AdminState) Enum() *AdminState {
+	p := new(AdminState)
+	*p = x
+	return p
+}
+
+func (x AdminState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AdminState) Descriptor() protoreflect.EnumDescriptor {
+	return file_aite_proto_enumTypes[3].Descriptor()
+}
+
+func (AdminState) Type() protoreflect.EnumType {
+	return &file_aite_proto_enumTypes[3]
+}
+
+func (x AdminState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AdminState.Descriptor instead.
+func (AdminState) EnumDescriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{3}
+}
+
+// OperState is the operational state of an interface as reported by the
+// kernel (RFC2863).
+type OperState int32
+
+const (
+	// The kernel does not know the operational state of the interface, this is
+	// the case for interfaces such as loopback that do not track state.
+	OperState_OS_UNSPECIFIED OperState = 0
+	// The interface is operationally up.
+	OperState_OS_UP OperState = 1
+	// The interface is operationally down.
+	OperState_OS_DOWN OperState = 2
+	// The interface is down due to the state of a lower-layer interface, for
+	// example the peer of a veth pair being down.
+	OperState_OS_LOWER_LAYER_DOWN OperState = 3
+	// The interface is in a test mode.
+	OperState_OS_TESTING OperState = 4
+	// The interface is waiting for an external event.
+	OperState_OS_DORMANT OperState = 5
+	// The interface is missing a component, typically hardware.
+	OperState_OS_NOT_PRESENT OperState = 6
+)
+
+// Enum value maps for OperState.
+var (
+	OperState_name = map[int32]string{
+		0: "OS_UNSPECIFIED",
+		1: "OS_UP",
+		2: "OS_DOWN",
+		3: "OS_LOWER_LAYER_DOWN",
+		4: "OS_TESTING",
+		5: "OS_DORMANT",
+		6: "OS_NOT_PRESENT",
+	}
+	OperState_value = map[string]int32{
+		"OS_UNSPECIFIED":      0,
+		"OS_UP":               1,
+		"OS_DOWN":             2,
+		"OS_LOWER_LAYER_DOWN": 3,
+		"OS_TESTING":          4,
+		"OS_DORMANT":          5,
+		"OS_NOT_PRESENT":      6,
+	}
+)
+
+func (x OperState) Enum() *OperState {
+	p := new(OperState)
+	*p = x
+	return p
+}
+
+func (x OperState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperState) Descriptor() protoreflect.EnumDescriptor {
+	return file_aite_proto_enumTypes[4].Descriptor()
+}
+
+func (OperState) Type() protoreflect.EnumType {
+	return &file_aite_proto_enumTypes[4]
+}
+
+func (x OperState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperState.Descriptor instead.
+func (OperState) EnumDescriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{4}
+}
+
+// EventType is the type of change described by a WatchInterfacesResponse.
+type EventType int32
+
+const (
+	// Invalid zero value.
+	EventType_ET_UNSPECIFIED EventType = 0
+	// The watch has been established, and all subsequent changes will be
+	// streamed. Sent once, as the first event on the stream.
+	EventType_ET_SYNC EventType = 1
+	// An interface was added.
+	EventType_ET_LINK_ADDED EventType = 2
+	// The administrative state, operational state or MTU of an interface
+	// changed.
+	EventType_ET_LINK_CHANGED EventType = 3
+	// An interface was removed.
+	EventType_ET_LINK_REMOVED EventType = 4
+	// A qdisc was installed on an interface, or its parameters changed.
+	EventType_ET_QDISC_CHANGED EventType = 5
+	// A qdisc was removed from an interface.
+	EventType_ET_QDISC_REMOVED EventType = 6
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "ET_UNSPECIFIED",
+		1: "ET_SYNC",
+		2: "ET_LINK_ADDED",
+		3: "ET_LINK_CHANGED",
+		4: "ET_LINK_REMOVED",
+		5: "ET_QDISC_CHANGED",
+		6: "ET_QDISC_REMOVED",
+	}
+	EventType_value = map[string]int32{
+		"ET_UNSPECIFIED":   0,
+		"ET_SYNC":          1,
+		"ET_LINK_ADDED":    2,
+		"ET_LINK_CHANGED":  3,
+		"ET_LINK_REMOVED":  4,
+		"ET_QDISC_CHANGED": 5,
+		"ET_QDISC_REMOVED": 6,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_aite_proto_enumTypes[5].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_aite_proto_enumTypes[5]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{5}
+}
+
 type SetInterfaceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -88,6 +408,34 @@ type SetInterfaceRequest struct {
 	// Declarative set of parameters that the interface should be configured
 	// with.
 	Params *InterfaceStateParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	// When specified, the state of the interface, and the qdisc installed on
+	// it, are restored to those prior to this request once the duration has
+	// elapsed, such that impairments are not left in place if the client fails.
+	// The restoration is cancelled if a subsequent request for the interface is
+	// received before it occurs.
+	DurationMsec uint32 `protobuf:"varint,3,opt,name=duration_msec,json=durationMsec,proto3" json:"duration_msec,omitempty"`
+	// When specified, the change is made under the lease with the specified ID,
+	// which must have been established by a KeepAlive stream that is still
+	// active. The interface is restored to its state prior to the lease's first
+	// change to it when the lease ends. The change is rejected if the interface
+	// is held by another lease, whereas a change made without a lease releases
+	// the interface from the lease that held it.
+	LeaseId string `protobuf:"bytes,4,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	// Impairments that are applied to specific flows traversing the interface
+	// in place of those specified in params. Flows are matched in the order in
+	// which they are specified, with packets that do not match any flow being
+	// impaired according to params. At most 15 flows can be specified.
+	Flows []*FlowImpairment `protobuf:"bytes,5,rep,name=flows,proto3" json:"flows,omitempty"`
+	// When specified, the interface is configured with the named profile that
+	// was loaded by the Aite server at startup. Any fields that are set in
+	// params override those of the profile, such that params need not be
+	// specified where the profile specifies the state of the interface. Since a
+	// field that is set to its zero value is indistinguishable from one that is
+	// not set, params cannot override a field of the profile to zero; for
+	// example, latency_msec of 0 leaves the latency of the profile in place.
+	// Where this is required, the params are to be specified in full without a
+	// profile.
+	ProfileName string `protobuf:"bytes,6,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"`
 }
 
 func (x *SetInterfaceRequest) Reset() {
@@ -136,24 +484,50 @@ func (x *SetInterfaceRequest) GetParams() *InterfaceStateParams {
 	return nil
 }
 
-type InterfaceStateParams struct {
+func (x *SetInterfaceRequest) GetDurationMsec() uint32 {
+	if x != nil {
+		return x.DurationMsec
+	}
+	return 0
+}
+
+func (x *SetInterfaceRequest) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
+}
+
+func (x *SetInterfaceRequest) GetFlows() []*FlowImpairment {
+	if x != nil {
+		return x.Flows
+	}
+	return nil
+}
+
+func (x *SetInterfaceRequest) GetProfileName() string {
+	if x != nil {
+		return x.ProfileName
+	}
+	return ""
+}
+
+// FlowImpairment specifies the impairments applied to a flow of packets.
+type FlowImpairment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The state that the interface should be in at the end of the state
-	// transition. This field must be specified.
-	State InterfaceState `protobuf:"varint,1,opt,name=state,proto3,enum=openconfig.aite.InterfaceState" json:"state,omitempty"`
-	// When specified, adds additional latency to packets traversing the interface.
-	// If set to the zero value, no additional latency is added.
-	LatencyMsec uint32 `protobuf:"varint,2,opt,name=latency_msec,json=latencyMsec,proto3" json:"latency_msec,omitempty"`
-	// When specified, adds loss with the specified percentage to packets traversing
-	// the interface. If set to the zero value, zero loss is injected.
-	LossPct uint32 `protobuf:"varint,3,opt,name=loss_pct,json=lossPct,proto3" json:"loss_pct,omitempty"`
+	// The packets to which the impairments are applied.
+	Match *FlowMatch `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	// The impairments applied to the packets. The state and direction fields
+	// are ignored, the flow being impaired in the direction specified for the
+	// interface.
+	Params *InterfaceStateParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
 }
 
-func (x *InterfaceStateParams) Reset() {
-	*x = InterfaceStateParams{}
+func (x *FlowImpairment) Reset() {
+	*x = FlowImpairment{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_aite_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -161,13 +535,13 @@ func (x *InterfaceStateParams) Reset() {
 	}
 }
 
-func (x *InterfaceStateParams) String() string {
+func (x *FlowImpairment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InterfaceStateParams) ProtoMessage() {}
+func (*FlowImpairment) ProtoMessage() {}
 
-func (x *InterfaceStateParams) ProtoReflect() protoreflect.Message {
+func (x *FlowImpairment) ProtoReflect() protoreflect.Message {
 	mi := &file_aite_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -179,45 +553,54 @@ func (x *InterfaceStateParams) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InterfaceStateParams.ProtoReflect.Descriptor instead.
-func (*InterfaceStateParams) Descriptor() ([]byte, []int) {
+// Deprecated: Use FlowImpairment.ProtoReflect.Descriptor instead.
+func (*FlowImpairment) Descriptor() ([]byte, []int) {
 	return file_aite_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *InterfaceStateParams) GetState() InterfaceState {
-	if x != nil {
-		return x.State
-	}
-	return InterfaceState_IS_UNSPECIFIED
-}
-
-func (x *InterfaceStateParams) GetLatencyMsec() uint32 {
+func (x *FlowImpairment) GetMatch() *FlowMatch {
 	if x != nil {
-		return x.LatencyMsec
+		return x.Match
 	}
-	return 0
+	return nil
 }
 
-func (x *InterfaceStateParams) GetLossPct() uint32 {
+func (x *FlowImpairment) GetParams() *InterfaceStateParams {
 	if x != nil {
-		return x.LossPct
+		return x.Params
 	}
-	return 0
+	return nil
 }
 
-// InterfaceStateResponse returns the intended state of the interface once an
-// interface state request has been accepted.
-type SetInterfaceResponse struct {
+// FlowMatch specifies the packets that belong to a flow. Packets must match
+// all fields that are specified. A FlowMatch with no fields specified matches
+// all packets.
+type FlowMatch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name   string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Params *InterfaceStateParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	// IP protocol number of the packets, e.g., 6 for TCP or 17 for UDP.
+	IpProtocol uint32 `protobuf:"varint,1,opt,name=ip_protocol,json=ipProtocol,proto3" json:"ip_protocol,omitempty"`
+	// Prefix that the source address of the packets is within, in CIDR form,
+	// e.g., 192.0.2.0/24 or 2001:db8::/32.
+	SrcPrefix string `protobuf:"bytes,2,opt,name=src_prefix,json=srcPrefix,proto3" json:"src_prefix,omitempty"`
+	// Prefix that the destination address of the packets is within, in CIDR
+	// form.
+	DstPrefix string `protobuf:"bytes,3,opt,name=dst_prefix,json=dstPrefix,proto3" json:"dst_prefix,omitempty"`
+	// Source port of the packets. Requires ip_protocol to be TCP, UDP or SCTP.
+	SrcPort uint32 `protobuf:"varint,4,opt,name=src_port,json=srcPort,proto3" json:"src_port,omitempty"`
+	// Destination port of the packets. Requires ip_protocol to be TCP, UDP or
+	// SCTP.
+	DstPort uint32 `protobuf:"varint,5,opt,name=dst_port,json=dstPort,proto3" json:"dst_port,omitempty"`
+	// DSCP value of the packets.
+	Dscp *uint32 `protobuf:"varint,6,opt,name=dscp,proto3,oneof" json:"dscp,omitempty"`
+	// VLAN ID of the packets.
+	VlanId uint32 `protobuf:"varint,7,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`
 }
 
-func (x *SetInterfaceResponse) Reset() {
-	*x = SetInterfaceResponse{}
+func (x *FlowMatch) Reset() {
+	*x = FlowMatch{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_aite_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -225,13 +608,13 @@ func (x *SetInterfaceResponse) Reset() {
 	}
 }
 
-func (x *SetInterfaceResponse) String() string {
+func (x *FlowMatch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetInterfaceResponse) ProtoMessage() {}
+func (*FlowMatch) ProtoMessage() {}
 
-func (x *SetInterfaceResponse) ProtoReflect() protoreflect.Message {
+func (x *FlowMatch) ProtoReflect() protoreflect.Message {
 	mi := &file_aite_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -243,109 +626,2715 @@ func (x *SetInterfaceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetInterfaceResponse.ProtoReflect.Descriptor instead.
-func (*SetInterfaceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FlowMatch.ProtoReflect.Descriptor instead.
+func (*FlowMatch) Descriptor() ([]byte, []int) {
 	return file_aite_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SetInterfaceResponse) GetName() string {
+func (x *FlowMatch) GetIpProtocol() uint32 {
 	if x != nil {
-		return x.Name
+		return x.IpProtocol
 	}
-	return ""
+	return 0
 }
 
-func (x *SetInterfaceResponse) GetParams() *InterfaceStateParams {
+func (x *FlowMatch) GetSrcPrefix() string {
 	if x != nil {
-		return x.Params
+		return x.SrcPrefix
 	}
-	return nil
+	return ""
 }
 
-var File_aite_proto protoreflect.FileDescriptor
+func (x *FlowMatch) GetDstPrefix() string {
+	if x != nil {
+		return x.DstPrefix
+	}
+	return ""
+}
 
-var file_aite_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6f, 0x70,
-	0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x22, 0x68, 0x0a,
-	0x13, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61,
-	0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
-	0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52,
-	0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x14, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x12, 0x35, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74,
-	0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e,
-	0x63, 0x79, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6c,
-	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f,
-	0x73, 0x73, 0x5f, 0x70, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6c, 0x6f,
-	0x73, 0x73, 0x50, 0x63, 0x74, 0x22, 0x69, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x3d, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61,
-	0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x2a, 0x42, 0x0a, 0x0e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
-	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x53, 0x5f, 0x55, 0x50, 0x10,
-	0x01, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x53, 0x5f, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x5f, 0x44, 0x4f,
-	0x57, 0x4e, 0x10, 0x02, 0x32, 0x63, 0x0a, 0x04, 0x41, 0x69, 0x74, 0x65, 0x12, 0x5b, 0x0a, 0x0c,
-	0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x24, 0x2e, 0x6f,
-	0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x53,
-	0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
-	0x61, 0x69, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2f, 0x61, 0x69, 0x74, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x69,
-	0x74, 0x65, 0x3b, 0x61, 0x69, 0x74, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *FlowMatch) GetSrcPort() uint32 {
+	if x != nil {
+		return x.SrcPort
+	}
+	return 0
 }
 
-var (
-	file_aite_proto_rawDescOnce sync.Once
-	file_aite_proto_rawDescData = file_aite_proto_rawDesc
-)
+func (x *FlowMatch) GetDstPort() uint32 {
+	if x != nil {
+		return x.DstPort
+	}
+	return 0
+}
 
-func file_aite_proto_rawDescGZIP() []byte {
-	file_aite_proto_rawDescOnce.Do(func() {
-		file_aite_proto_rawDescData = protoimpl.X.CompressGZIP(file_aite_proto_rawDescData)
-	})
-	return file_aite_proto_rawDescData
+func (x *FlowMatch) GetDscp() uint32 {
+	if x != nil && x.Dscp != nil {
+		return *x.Dscp
+	}
+	return 0
 }
 
-var file_aite_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_aite_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_aite_proto_goTypes = []interface{}{
-	(InterfaceState)(0),          // 0: openconfig.aite.InterfaceState
-	(*SetInterfaceRequest)(nil),  // 1: openconfig.aite.SetInterfaceRequest
-	(*InterfaceStateParams)(nil), // 2: openconfig.aite.InterfaceStateParams
-	(*SetInterfaceResponse)(nil), // 3: openconfig.aite.SetInterfaceResponse
+func (x *FlowMatch) GetVlanId() uint32 {
+	if x != nil {
+		return x.VlanId
+	}
+	return 0
 }
-var file_aite_proto_depIdxs = []int32{
-	2, // 0: openconfig.aite.SetInterfaceRequest.params:type_name -> openconfig.aite.InterfaceStateParams
-	0, // 1: openconfig.aite.InterfaceStateParams.state:type_name -> openconfig.aite.InterfaceState
-	2, // 2: openconfig.aite.SetInterfaceResponse.params:type_name -> openconfig.aite.InterfaceStateParams
-	1, // 3: openconfig.aite.Aite.SetInterface:input_type -> openconfig.aite.SetInterfaceRequest
-	3, // 4: openconfig.aite.Aite.SetInterface:output_type -> openconfig.aite.SetInterfaceResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+
+type InterfaceStateParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The state that the interface should be in at the end of the state
+	// transition. This field must be specified.
+	State InterfaceState `protobuf:"varint,1,opt,name=state,proto3,enum=openconfig.aite.InterfaceState" json:"state,omitempty"`
+	// When specified, adds additional latency to packets traversing the
+	// interface. If set to the zero value, no additional latency is added.
+	LatencyMsec uint32 `protobuf:"varint,2,opt,name=latency_msec,json=latencyMsec,proto3" json:"latency_msec,omitempty"`
+	// When specified, adds loss with the specified percentage to packets
+	// traversing the interface. If set to the zero value, zero loss is
+	// injected.
+	LossPct uint32 `protobuf:"varint,3,opt,name=loss_pct,json=lossPct,proto3" json:"loss_pct,omitempty"`
+	// When specified, varies the latency added to packets traversing the
+	// interface by up to the specified value, such that the latency of a
+	// packet is latency_msec +/- jitter_msec.
+	JitterMsec uint32 `protobuf:"varint,4,opt,name=jitter_msec,json=jitterMsec,proto3" json:"jitter_msec,omitempty"`
+	// Correlation of the latency added to a packet with the latency added to
+	// the previous packet, expressed as a percentage.
+	LatencyCorrelationPct uint32 `protobuf:"varint,5,opt,name=latency_correlation_pct,json=latencyCorrelationPct,proto3" json:"latency_correlation_pct,omitempty"`
+	// Distribution from which the jitter added to packets is drawn. Distributions
+	// other than uniform require jitter_msec to be specified.
+	DelayDistribution DelayDistribution `protobuf:"varint,6,opt,name=delay_distribution,json=delayDistribution,proto3,enum=openconfig.aite.DelayDistribution" json:"delay_distribution,omitempty"`
+	// When specified, duplicates the specified percentage of packets
+	// traversing the interface.
+	DuplicatePct uint32 `protobuf:"varint,7,opt,name=duplicate_pct,json=duplicatePct,proto3" json:"duplicate_pct,omitempty"`
+	// Correlation of the decision to duplicate a packet with the decision made
+	// for the previous packet, expressed as a percentage.
+	DuplicateCorrelationPct uint32 `protobuf:"varint,8,opt,name=duplicate_correlation_pct,json=duplicateCorrelationPct,proto3" json:"duplicate_correlation_pct,omitempty"`
+	// When specified, introduces a single bit error at a random offset in the
+	// specified percentage of packets traversing the interface.
+	CorruptPct uint32 `protobuf:"varint,9,opt,name=corrupt_pct,json=corruptPct,proto3" json:"corrupt_pct,omitempty"`
+	// Correlation of the decision to corrupt a packet with the decision made
+	// for the previous packet, expressed as a percentage.
+	CorruptCorrelationPct uint32 `protobuf:"varint,10,opt,name=corrupt_correlation_pct,json=corruptCorrelationPct,proto3" json:"corrupt_correlation_pct,omitempty"`
+	// When specified, the specified percentage of packets traversing the
+	// interface are sent immediately, whilst the remainder are delayed by
+	// latency_msec, such that packets are reordered. Requires latency_msec to
+	// be specified.
+	ReorderPct uint32 `protobuf:"varint,11,opt,name=reorder_pct,json=reorderPct,proto3" json:"reorder_pct,omitempty"`
+	// Correlation of the decision to reorder a packet with the decision made
+	// for the previous packet, expressed as a percentage.
+	ReorderCorrelationPct uint32 `protobuf:"varint,12,opt,name=reorder_correlation_pct,json=reorderCorrelationPct,proto3" json:"reorder_correlation_pct,omitempty"`
+	// When specified, adds loss at the specified rate, expressed in parts per
+	// million, to packets traversing the interface. This allows loss of less
+	// than one percent to be specified, e.g., 0.01% loss is 100 ppm. Cannot be
+	// specified alongside loss_pct.
+	LossPpm uint32 `protobuf:"varint,13,opt,name=loss_ppm,json=lossPpm,proto3" json:"loss_ppm,omitempty"`
+	// When specified, packets traversing the interface are lost according to
+	// the specified model rather than uniformly at random. A loss model cannot
+	// be specified alongside loss_pct or loss_ppm.
+	//
+	// Types that are assignable to LossModel:
+	//	*InterfaceStateParams_GilbertElliott
+	//	*InterfaceStateParams_FourState
+	LossModel isInterfaceStateParams_LossModel `protobuf_oneof:"loss_model"`
+	// When specified, limits the rate at which packets traverse the interface
+	// to the specified number of bits per second, emulating a link of that
+	// bandwidth. If set to the zero value, the rate is not limited.
+	RateBps uint64 `protobuf:"varint,16,opt,name=rate_bps,json=rateBps,proto3" json:"rate_bps,omitempty"`
+	// Overhead in bytes that is added to the size of each packet when
+	// calculating the time taken to transmit it at rate_bps. Negative values
+	// reduce the size of each packet.
+	RatePacketOverhead int32 `protobuf:"varint,17,opt,name=rate_packet_overhead,json=ratePacketOverhead,proto3" json:"rate_packet_overhead,omitempty"`
+	// When specified, packets are transmitted as a whole number of cells of
+	// the specified size in bytes when calculating the time taken to transmit
+	// them at rate_bps, e.g., 48 for ATM.
+	RateCellSize uint32 `protobuf:"varint,18,opt,name=rate_cell_size,json=rateCellSize,proto3" json:"rate_cell_size,omitempty"`
+	// Direction of the traffic traversing the interface to which impairments
+	// are applied. If set to the zero value, impairments are applied to
+	// packets transmitted by the interface.
+	Direction Direction `protobuf:"varint,19,opt,name=direction,proto3,enum=openconfig.aite.Direction" json:"direction,omitempty"`
 }
 
-func init() { file_aite_proto_init() }
-func file_aite_proto_init() {
-	if File_aite_proto != nil {
-		return
+func (x *InterfaceStateParams) Reset() {
+	*x = InterfaceStateParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_aite_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetInterfaceRequest); i {
+}
+
+func (x *InterfaceStateParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceStateParams) ProtoMessage() {}
+
+func (x *InterfaceStateParams) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceStateParams.ProtoReflect.Descriptor instead.
+func (*InterfaceStateParams) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InterfaceStateParams) GetState() InterfaceState {
+	if x != nil {
+		return x.State
+	}
+	return InterfaceState_IS_UNSPECIFIED
+}
+
+func (x *InterfaceStateParams) GetLatencyMsec() uint32 {
+	if x != nil {
+		return x.LatencyMsec
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetLossPct() uint32 {
+	if x != nil {
+		return x.LossPct
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetJitterMsec() uint32 {
+	if x != nil {
+		return x.JitterMsec
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetLatencyCorrelationPct() uint32 {
+	if x != nil {
+		return x.LatencyCorrelationPct
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetDelayDistribution() DelayDistribution {
+	if x != nil {
+		return x.DelayDistribution
+	}
+	return DelayDistribution_DD_UNIFORM
+}
+
+func (x *InterfaceStateParams) GetDuplicatePct() uint32 {
+	if x != nil {
+		return x.DuplicatePct
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetDuplicateCorrelationPct() uint32 {
+	if x != nil {
+		return x.DuplicateCorrelationPct
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetCorruptPct() uint32 {
+	if x != nil {
+		return x.CorruptPct
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetCorruptCorrelationPct() uint32 {
+	if x != nil {
+		return x.CorruptCorrelationPct
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetReorderPct() uint32 {
+	if x != nil {
+		return x.ReorderPct
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetReorderCorrelationPct() uint32 {
+	if x != nil {
+		return x.ReorderCorrelationPct
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetLossPpm() uint32 {
+	if x != nil {
+		return x.LossPpm
+	}
+	return 0
+}
+
+func (m *InterfaceStateParams) GetLossModel() isInterfaceStateParams_LossModel {
+	if m != nil {
+		return m.LossModel
+	}
+	return nil
+}
+
+func (x *InterfaceStateParams) GetGilbertElliott() *GilbertElliottLossModel {
+	if x, ok := x.GetLossModel().(*InterfaceStateParams_GilbertElliott); ok {
+		return x.GilbertElliott
+	}
+	return nil
+}
+
+func (x *InterfaceStateParams) GetFourState() *FourStateLossModel {
+	if x, ok := x.GetLossModel().(*InterfaceStateParams_FourState); ok {
+		return x.FourState
+	}
+	return nil
+}
+
+func (x *InterfaceStateParams) GetRateBps() uint64 {
+	if x != nil {
+		return x.RateBps
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetRatePacketOverhead() int32 {
+	if x != nil {
+		return x.RatePacketOverhead
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetRateCellSize() uint32 {
+	if x != nil {
+		return x.RateCellSize
+	}
+	return 0
+}
+
+func (x *InterfaceStateParams) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIR_EGRESS
+}
+
+type isInterfaceStateParams_LossModel interface {
+	isInterfaceStateParams_LossModel()
+}
+
+type InterfaceStateParams_GilbertElliott struct {
+	GilbertElliott *GilbertElliottLossModel `protobuf:"bytes,14,opt,name=gilbert_elliott,json=gilbertElliott,proto3,oneof"`
+}
+
+type InterfaceStateParams_FourState struct {
+	FourState *FourStateLossModel `protobuf:"bytes,15,opt,name=four_state,json=fourState,proto3,oneof"`
+}
+
+func (*InterfaceStateParams_GilbertElliott) isInterfaceStateParams_LossModel() {}
+
+func (*InterfaceStateParams_FourState) isInterfaceStateParams_LossModel() {}
+
+// GilbertElliottLossModel specifies a Gilbert-Elliott loss model, in which the
+// interface moves between a good and a bad state, each of which has its own
+// loss probability, such that bursts of loss can be produced. It corresponds
+// to netem's gemodel. All probabilities are expressed in parts per million.
+type GilbertElliottLossModel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Probability of moving from the good state to the bad state.
+	PPpm uint32 `protobuf:"varint,1,opt,name=p_ppm,json=pPpm,proto3" json:"p_ppm,omitempty"`
+	// Probability of moving from the bad state to the good state.
+	RPpm uint32 `protobuf:"varint,2,opt,name=r_ppm,json=rPpm,proto3" json:"r_ppm,omitempty"`
+	// Probability of a packet being lost in the bad state (1-h).
+	BadLossPpm uint32 `protobuf:"varint,3,opt,name=bad_loss_ppm,json=badLossPpm,proto3" json:"bad_loss_ppm,omitempty"`
+	// Probability of a packet being lost in the good state (1-k).
+	GoodLossPpm uint32 `protobuf:"varint,4,opt,name=good_loss_ppm,json=goodLossPpm,proto3" json:"good_loss_ppm,omitempty"`
+}
+
+func (x *GilbertElliottLossModel) Reset() {
+	*x = GilbertElliottLossModel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GilbertElliottLossModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GilbertElliottLossModel) ProtoMessage() {}
+
+func (x *GilbertElliottLossModel) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GilbertElliottLossModel.ProtoReflect.Descriptor instead.
+func (*GilbertElliottLossModel) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GilbertElliottLossModel) GetPPpm() uint32 {
+	if x != nil {
+		return x.PPpm
+	}
+	return 0
+}
+
+func (x *GilbertElliottLossModel) GetRPpm() uint32 {
+	if x != nil {
+		return x.RPpm
+	}
+	return 0
+}
+
+func (x *GilbertElliottLossModel) GetBadLossPpm() uint32 {
+	if x != nil {
+		return x.BadLossPpm
+	}
+	return 0
+}
+
+func (x *GilbertElliottLossModel) GetGoodLossPpm() uint32 {
+	if x != nil {
+		return x.GoodLossPpm
+	}
+	return 0
+}
+
+// FourStateLossModel specifies a 4-state Markov loss model, as described in
+// "Definition of a general and intuitive loss model for packet networks and
+// its implementation in the Netem module in the Linux kernel" (Salsano et
+// al.), corresponding to netem's state loss model. The states are:
+//  1. good reception within a gap period,
+//  2. good reception within a burst period,
+//  3. loss within a burst period,
+//  4. isolated loss within a gap period.
+//
+// All probabilities are expressed in parts per million.
+type FourStateLossModel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Probability of moving from state 1 to state 3.
+	P13Ppm uint32 `protobuf:"varint,1,opt,name=p13_ppm,json=p13Ppm,proto3" json:"p13_ppm,omitempty"`
+	// Probability of moving from state 3 to state 1.
+	P31Ppm uint32 `protobuf:"varint,2,opt,name=p31_ppm,json=p31Ppm,proto3" json:"p31_ppm,omitempty"`
+	// Probability of moving from state 3 to state 2.
+	P32Ppm uint32 `protobuf:"varint,3,opt,name=p32_ppm,json=p32Ppm,proto3" json:"p32_ppm,omitempty"`
+	// Probability of moving from state 1 to state 4.
+	P14Ppm uint32 `protobuf:"varint,4,opt,name=p14_ppm,json=p14Ppm,proto3" json:"p14_ppm,omitempty"`
+	// Probability of moving from state 2 to state 3.
+	P23Ppm uint32 `protobuf:"varint,5,opt,name=p23_ppm,json=p23Ppm,proto3" json:"p23_ppm,omitempty"`
+}
+
+func (x *FourStateLossModel) Reset() {
+	*x = FourStateLossModel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FourStateLossModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FourStateLossModel) ProtoMessage() {}
+
+func (x *FourStateLossModel) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FourStateLossModel.ProtoReflect.Descriptor instead.
+func (*FourStateLossModel) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FourStateLossModel) GetP13Ppm() uint32 {
+	if x != nil {
+		return x.P13Ppm
+	}
+	return 0
+}
+
+func (x *FourStateLossModel) GetP31Ppm() uint32 {
+	if x != nil {
+		return x.P31Ppm
+	}
+	return 0
+}
+
+func (x *FourStateLossModel) GetP32Ppm() uint32 {
+	if x != nil {
+		return x.P32Ppm
+	}
+	return 0
+}
+
+func (x *FourStateLossModel) GetP14Ppm() uint32 {
+	if x != nil {
+		return x.P14Ppm
+	}
+	return 0
+}
+
+func (x *FourStateLossModel) GetP23Ppm() uint32 {
+	if x != nil {
+		return x.P23Ppm
+	}
+	return 0
+}
+
+// InterfaceStateResponse returns the intended state of the interface once an
+// interface state request has been accepted.
+type SetInterfaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Params *InterfaceStateParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	// changed is false when the interface was already in the requested state,
+	// such that nothing was programmed.
+	Changed bool `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+}
+
+func (x *SetInterfaceResponse) Reset() {
+	*x = SetInterfaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetInterfaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetInterfaceResponse) ProtoMessage() {}
+
+func (x *SetInterfaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetInterfaceResponse.ProtoReflect.Descriptor instead.
+func (*SetInterfaceResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetInterfaceResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetInterfaceResponse) GetParams() *InterfaceStateParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *SetInterfaceResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+// Qdisc describes a queueing discipline installed on an interface.
+type Qdisc struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The kind of the qdisc, e.g., netem, noqueue, fq_codel.
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The handle of the qdisc, expressed in the form major:minor as used by
+	// tc.
+	Handle string `protobuf:"bytes,2,opt,name=handle,proto3" json:"handle,omitempty"`
+	// The handle of the parent of the qdisc, expressed in the form major:minor
+	// as used by tc, where root qdiscs have the parent ffff:ffff. Set only within
+	// WatchInterfacesResponse and QdiscStats.
+	Parent string `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+}
+
+func (x *Qdisc) Reset() {
+	*x = Qdisc{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Qdisc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Qdisc) ProtoMessage() {}
+
+func (x *Qdisc) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Qdisc.ProtoReflect.Descriptor instead.
+func (*Qdisc) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Qdisc) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Qdisc) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *Qdisc) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+// Interface describes an interface within the target pod.
+type Interface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the interface.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Kernel index of the interface.
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// MAC address of the interface, in colon-separated hexadecimal form.
+	Mac string `protobuf:"bytes,3,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Maximum transmission unit of the interface in bytes.
+	Mtu uint32 `protobuf:"varint,4,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	// Administrative state of the interface.
+	AdminState AdminState `protobuf:"varint,5,opt,name=admin_state,json=adminState,proto3,enum=openconfig.aite.AdminState" json:"admin_state,omitempty"`
+	// Operational state of the interface.
+	OperState OperState `protobuf:"varint,6,opt,name=oper_state,json=operState,proto3,enum=openconfig.aite.OperState" json:"oper_state,omitempty"`
+	// The qdisc that is currently installed at the root of the interface. Unset
+	// if no root qdisc could be found.
+	RootQdisc *Qdisc `protobuf:"bytes,7,opt,name=root_qdisc,json=rootQdisc,proto3" json:"root_qdisc,omitempty"`
+}
+
+func (x *Interface) Reset() {
+	*x = Interface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Interface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interface) ProtoMessage() {}
+
+func (x *Interface) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interface.ProtoReflect.Descriptor instead.
+func (*Interface) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Interface) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Interface) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Interface) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *Interface) GetMtu() uint32 {
+	if x != nil {
+		return x.Mtu
+	}
+	return 0
+}
+
+func (x *Interface) GetAdminState() AdminState {
+	if x != nil {
+		return x.AdminState
+	}
+	return AdminState_AS_UNSPECIFIED
+}
+
+func (x *Interface) GetOperState() OperState {
+	if x != nil {
+		return x.OperState
+	}
+	return OperState_OS_UNSPECIFIED
+}
+
+func (x *Interface) GetRootQdisc() *Qdisc {
+	if x != nil {
+		return x.RootQdisc
+	}
+	return nil
+}
+
+type ListInterfacesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListInterfacesRequest) Reset() {
+	*x = ListInterfacesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInterfacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterfacesRequest) ProtoMessage() {}
+
+func (x *ListInterfacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterfacesRequest.ProtoReflect.Descriptor instead.
+func (*ListInterfacesRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{9}
+}
+
+type ListInterfacesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Interfaces that exist within the target pod.
+	Interfaces []*Interface `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *ListInterfacesResponse) Reset() {
+	*x = ListInterfacesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInterfacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterfacesResponse) ProtoMessage() {}
+
+func (x *ListInterfacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterfacesResponse.ProtoReflect.Descriptor instead.
+func (*ListInterfacesResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListInterfacesResponse) GetInterfaces() []*Interface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+type GetInterfaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the interface that is to be retrieved.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetInterfaceRequest) Reset() {
+	*x = GetInterfaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInterfaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInterfaceRequest) ProtoMessage() {}
+
+func (x *GetInterfaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInterfaceRequest.ProtoReflect.Descriptor instead.
+func (*GetInterfaceRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetInterfaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetInterfaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Details of the interface.
+	Interface *Interface `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	// The parameters that are currently applied to the interface, as read back
+	// from the kernel rather than those that were last requested.
+	Params *InterfaceStateParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	// The impairments that are currently applied to flows traversing the
+	// interface. The match of each flow is that which was requested, whilst
+	// its parameters are read back from the kernel.
+	Flows []*FlowImpairment `protobuf:"bytes,3,rep,name=flows,proto3" json:"flows,omitempty"`
+}
+
+func (x *GetInterfaceResponse) Reset() {
+	*x = GetInterfaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInterfaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInterfaceResponse) ProtoMessage() {}
+
+func (x *GetInterfaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInterfaceResponse.ProtoReflect.Descriptor instead.
+func (*GetInterfaceResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetInterfaceResponse) GetInterface() *Interface {
+	if x != nil {
+		return x.Interface
+	}
+	return nil
+}
+
+func (x *GetInterfaceResponse) GetParams() *InterfaceStateParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *GetInterfaceResponse) GetFlows() []*FlowImpairment {
+	if x != nil {
+		return x.Flows
+	}
+	return nil
+}
+
+type ClearInterfaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the interface that is to be cleared.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *ClearInterfaceRequest) Reset() {
+	*x = ClearInterfaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearInterfaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearInterfaceRequest) ProtoMessage() {}
+
+func (x *ClearInterfaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearInterfaceRequest.ProtoReflect.Descriptor instead.
+func (*ClearInterfaceRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ClearInterfaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ClearInterfaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Details of the interface once impairments have been removed.
+	Interface *Interface `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+}
+
+func (x *ClearInterfaceResponse) Reset() {
+	*x = ClearInterfaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearInterfaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearInterfaceResponse) ProtoMessage() {}
+
+func (x *ClearInterfaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearInterfaceResponse.ProtoReflect.Descriptor instead.
+func (*ClearInterfaceResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ClearInterfaceResponse) GetInterface() *Interface {
+	if x != nil {
+		return x.Interface
+	}
+	return nil
+}
+
+type FlapInterfaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the interface that is to be flapped.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Duration for which the interface is held down in each flap.
+	DownMsec uint32 `protobuf:"varint,2,opt,name=down_msec,json=downMsec,proto3" json:"down_msec,omitempty"`
+	// Duration for which the interface is held up after each flap before the
+	// next flap begins.
+	UpMsec uint32 `protobuf:"varint,3,opt,name=up_msec,json=upMsec,proto3" json:"up_msec,omitempty"`
+	// Number of times that the interface is to be flapped. If set to the zero
+	// value, the interface is flapped until the RPC is cancelled.
+	Count uint32 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *FlapInterfaceRequest) Reset() {
+	*x = FlapInterfaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlapInterfaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlapInterfaceRequest) ProtoMessage() {}
+
+func (x *FlapInterfaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlapInterfaceRequest.ProtoReflect.Descriptor instead.
+func (*FlapInterfaceRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FlapInterfaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FlapInterfaceRequest) GetDownMsec() uint32 {
+	if x != nil {
+		return x.DownMsec
+	}
+	return 0
+}
+
+func (x *FlapInterfaceRequest) GetUpMsec() uint32 {
+	if x != nil {
+		return x.UpMsec
+	}
+	return 0
+}
+
+func (x *FlapInterfaceRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// FlapInterfaceResponse describes a single transition of an interface that is
+// being flapped.
+type FlapInterfaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the interface that transitioned.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The state that the interface transitioned to, either IS_UP or
+	// IS_ADMIN_DOWN.
+	State InterfaceState `protobuf:"varint,2,opt,name=state,proto3,enum=openconfig.aite.InterfaceState" json:"state,omitempty"`
+	// The flap to which the transition belongs, starting from 1.
+	Iteration uint32 `protobuf:"varint,3,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	// Time at which the transition occurred, expressed in nanoseconds since the
+	// Unix epoch.
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *FlapInterfaceResponse) Reset() {
+	*x = FlapInterfaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlapInterfaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlapInterfaceResponse) ProtoMessage() {}
+
+func (x *FlapInterfaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlapInterfaceResponse.ProtoReflect.Descriptor instead.
+func (*FlapInterfaceResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FlapInterfaceResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FlapInterfaceResponse) GetState() InterfaceState {
+	if x != nil {
+		return x.State
+	}
+	return InterfaceState_IS_UNSPECIFIED
+}
+
+func (x *FlapInterfaceResponse) GetIteration() uint32 {
+	if x != nil {
+		return x.Iteration
+	}
+	return 0
+}
+
+func (x *FlapInterfaceResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// KeepAliveRequest is a heartbeat sent by the client to hold a lease.
+type KeepAliveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Time within which the next heartbeat must be received for the lease to
+	// be held. This field must be specified in the first heartbeat on a stream,
+	// and when specified in subsequent heartbeats, updates the timeout.
+	TimeoutMsec uint32 `protobuf:"varint,1,opt,name=timeout_msec,json=timeoutMsec,proto3" json:"timeout_msec,omitempty"`
+}
+
+func (x *KeepAliveRequest) Reset() {
+	*x = KeepAliveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeepAliveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeepAliveRequest) ProtoMessage() {}
+
+func (x *KeepAliveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeepAliveRequest.ProtoReflect.Descriptor instead.
+func (*KeepAliveRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *KeepAliveRequest) GetTimeoutMsec() uint32 {
+	if x != nil {
+		return x.TimeoutMsec
+	}
+	return 0
+}
+
+// KeepAliveResponse acknowledges a heartbeat received from the client.
+type KeepAliveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the lease held by the stream, to be specified in requests that
+	// should be rolled back when the lease ends.
+	LeaseId string `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+}
+
+func (x *KeepAliveResponse) Reset() {
+	*x = KeepAliveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeepAliveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeepAliveResponse) ProtoMessage() {}
+
+func (x *KeepAliveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeepAliveResponse.ProtoReflect.Descriptor instead.
+func (*KeepAliveResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *KeepAliveResponse) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
+}
+
+// BatchSetInterfacesRequest specifies the changes to be made to a set of
+// interfaces.
+type BatchSetInterfacesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Changes to be made, in the order in which they are applied. Each
+	// interface may be specified at most once.
+	Requests []*SetInterfaceRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (x *BatchSetInterfacesRequest) Reset() {
+	*x = BatchSetInterfacesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSetInterfacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetInterfacesRequest) ProtoMessage() {}
+
+func (x *BatchSetInterfacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetInterfacesRequest.ProtoReflect.Descriptor instead.
+func (*BatchSetInterfacesRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BatchSetInterfacesRequest) GetRequests() []*SetInterfaceRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// BatchSetInterfacesResponse returns the intended state of each interface
+// once a batch has been applied, in the order of the request.
+type BatchSetInterfacesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Responses []*SetInterfaceResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+}
+
+func (x *BatchSetInterfacesResponse) Reset() {
+	*x = BatchSetInterfacesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSetInterfacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetInterfacesResponse) ProtoMessage() {}
+
+func (x *BatchSetInterfacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetInterfacesResponse.ProtoReflect.Descriptor instead.
+func (*BatchSetInterfacesResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BatchSetInterfacesResponse) GetResponses() []*SetInterfaceResponse {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+// WatchInterfacesRequest specifies the interfaces for which events are to be
+// streamed.
+type WatchInterfacesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Names of the interfaces to watch. Events for all interfaces are streamed
+	// if no names are specified.
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *WatchInterfacesRequest) Reset() {
+	*x = WatchInterfacesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchInterfacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInterfacesRequest) ProtoMessage() {}
+
+func (x *WatchInterfacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInterfacesRequest.ProtoReflect.Descriptor instead.
+func (*WatchInterfacesRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchInterfacesRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+// WatchInterfacesResponse describes a change to an interface.
+type WatchInterfacesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type EventType `protobuf:"varint,1,opt,name=type,proto3,enum=openconfig.aite.EventType" json:"type,omitempty"`
+	// State of the interface once the change occurred. For removed interfaces,
+	// the state of the interface when it was removed. Unset for ET_SYNC.
+	Interface *Interface `protobuf:"bytes,2,opt,name=interface,proto3" json:"interface,omitempty"`
+	// The qdisc that changed, set only for qdisc events.
+	Qdisc *Qdisc `protobuf:"bytes,3,opt,name=qdisc,proto3" json:"qdisc,omitempty"`
+	// Time at which Aite received notification of the change, expressed in
+	// nanoseconds since the Unix epoch.
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *WatchInterfacesResponse) Reset() {
+	*x = WatchInterfacesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchInterfacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInterfacesResponse) ProtoMessage() {}
+
+func (x *WatchInterfacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInterfacesResponse.ProtoReflect.Descriptor instead.
+func (*WatchInterfacesResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchInterfacesResponse) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_ET_UNSPECIFIED
+}
+
+func (x *WatchInterfacesResponse) GetInterface() *Interface {
+	if x != nil {
+		return x.Interface
+	}
+	return nil
+}
+
+func (x *WatchInterfacesResponse) GetQdisc() *Qdisc {
+	if x != nil {
+		return x.Qdisc
+	}
+	return nil
+}
+
+func (x *WatchInterfacesResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type GetInterfaceStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the interface for which statistics are to be returned.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetInterfaceStatsRequest) Reset() {
+	*x = GetInterfaceStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInterfaceStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInterfaceStatsRequest) ProtoMessage() {}
+
+func (x *GetInterfaceStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInterfaceStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetInterfaceStatsRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetInterfaceStatsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// InterfaceCounters are the packet counters maintained by the kernel for an
+// interface.
+type InterfaceCounters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RxPackets uint64 `protobuf:"varint,1,opt,name=rx_packets,json=rxPackets,proto3" json:"rx_packets,omitempty"`
+	TxPackets uint64 `protobuf:"varint,2,opt,name=tx_packets,json=txPackets,proto3" json:"tx_packets,omitempty"`
+	RxBytes   uint64 `protobuf:"varint,3,opt,name=rx_bytes,json=rxBytes,proto3" json:"rx_bytes,omitempty"`
+	TxBytes   uint64 `protobuf:"varint,4,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	RxErrors  uint64 `protobuf:"varint,5,opt,name=rx_errors,json=rxErrors,proto3" json:"rx_errors,omitempty"`
+	TxErrors  uint64 `protobuf:"varint,6,opt,name=tx_errors,json=txErrors,proto3" json:"tx_errors,omitempty"`
+	RxDropped uint64 `protobuf:"varint,7,opt,name=rx_dropped,json=rxDropped,proto3" json:"rx_dropped,omitempty"`
+	TxDropped uint64 `protobuf:"varint,8,opt,name=tx_dropped,json=txDropped,proto3" json:"tx_dropped,omitempty"`
+}
+
+func (x *InterfaceCounters) Reset() {
+	*x = InterfaceCounters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InterfaceCounters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceCounters) ProtoMessage() {}
+
+func (x *InterfaceCounters) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceCounters.ProtoReflect.Descriptor instead.
+func (*InterfaceCounters) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *InterfaceCounters) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRxErrors() uint64 {
+	if x != nil {
+		return x.RxErrors
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxErrors() uint64 {
+	if x != nil {
+		return x.TxErrors
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRxDropped() uint64 {
+	if x != nil {
+		return x.RxDropped
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxDropped() uint64 {
+	if x != nil {
+		return x.TxDropped
+	}
+	return 0
+}
+
+// QdiscStats are the statistics maintained by the kernel for a qdisc.
+type QdiscStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the device on which the qdisc is installed, which is either the
+	// interface itself, or the ifb device to which its ingress traffic is
+	// redirected.
+	Device string `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	// The qdisc, including its parent.
+	Qdisc *Qdisc `protobuf:"bytes,2,opt,name=qdisc,proto3" json:"qdisc,omitempty"`
+	// Number of bytes and packets that have been dequeued by the qdisc.
+	Bytes   uint64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Packets uint64 `protobuf:"varint,4,opt,name=packets,proto3" json:"packets,omitempty"`
+	// Number of packets that have been dropped by the qdisc. For netem qdiscs,
+	// this includes the packets dropped to emulate loss.
+	Drops uint32 `protobuf:"varint,5,opt,name=drops,proto3" json:"drops,omitempty"`
+	// Number of times that the qdisc has been over its limit.
+	Overlimits uint32 `protobuf:"varint,6,opt,name=overlimits,proto3" json:"overlimits,omitempty"`
+	// Number of packets that have been requeued by the qdisc.
+	Requeues uint32 `protobuf:"varint,7,opt,name=requeues,proto3" json:"requeues,omitempty"`
+	// Number of bytes and packets currently queued by the qdisc.
+	BacklogBytes uint32 `protobuf:"varint,8,opt,name=backlog_bytes,json=backlogBytes,proto3" json:"backlog_bytes,omitempty"`
+	Qlen         uint32 `protobuf:"varint,9,opt,name=qlen,proto3" json:"qlen,omitempty"`
+}
+
+func (x *QdiscStats) Reset() {
+	*x = QdiscStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QdiscStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QdiscStats) ProtoMessage() {}
+
+func (x *QdiscStats) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QdiscStats.ProtoReflect.Descriptor instead.
+func (*QdiscStats) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QdiscStats) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *QdiscStats) GetQdisc() *Qdisc {
+	if x != nil {
+		return x.Qdisc
+	}
+	return nil
+}
+
+func (x *QdiscStats) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *QdiscStats) GetPackets() uint64 {
+	if x != nil {
+		return x.Packets
+	}
+	return 0
+}
+
+func (x *QdiscStats) GetDrops() uint32 {
+	if x != nil {
+		return x.Drops
+	}
+	return 0
+}
+
+func (x *QdiscStats) GetOverlimits() uint32 {
+	if x != nil {
+		return x.Overlimits
+	}
+	return 0
+}
+
+func (x *QdiscStats) GetRequeues() uint32 {
+	if x != nil {
+		return x.Requeues
+	}
+	return 0
+}
+
+func (x *QdiscStats) GetBacklogBytes() uint32 {
+	if x != nil {
+		return x.BacklogBytes
+	}
+	return 0
+}
+
+func (x *QdiscStats) GetQlen() uint32 {
+	if x != nil {
+		return x.Qlen
+	}
+	return 0
+}
+
+type GetInterfaceStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Interface for which statistics are returned.
+	Interface *Interface `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	// Counters of the interface.
+	Counters *InterfaceCounters `protobuf:"bytes,2,opt,name=counters,proto3" json:"counters,omitempty"`
+	// Statistics of each qdisc installed on the interface, and on the device to
+	// which its ingress traffic is redirected, ordered by device and parent.
+	Qdiscs []*QdiscStats `protobuf:"bytes,3,rep,name=qdiscs,proto3" json:"qdiscs,omitempty"`
+}
+
+func (x *GetInterfaceStatsResponse) Reset() {
+	*x = GetInterfaceStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInterfaceStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInterfaceStatsResponse) ProtoMessage() {}
+
+func (x *GetInterfaceStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInterfaceStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetInterfaceStatsResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetInterfaceStatsResponse) GetInterface() *Interface {
+	if x != nil {
+		return x.Interface
+	}
+	return nil
+}
+
+func (x *GetInterfaceStatsResponse) GetCounters() *InterfaceCounters {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+func (x *GetInterfaceStatsResponse) GetQdiscs() []*QdiscStats {
+	if x != nil {
+		return x.Qdiscs
+	}
+	return nil
+}
+
+// ClearAllInterfaces specifies that the impairments applied to every interface
+// that Aite has changed are removed, as per ClearInterface.
+type ClearAllInterfaces struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClearAllInterfaces) Reset() {
+	*x = ClearAllInterfaces{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearAllInterfaces) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearAllInterfaces) ProtoMessage() {}
+
+func (x *ClearAllInterfaces) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearAllInterfaces.ProtoReflect.Descriptor instead.
+func (*ClearAllInterfaces) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{27}
+}
+
+// ScenarioStep is a change made at a point within a scenario.
+type ScenarioStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Time at which the step is executed, relative to the start of the
+	// scenario. Steps must be specified in order of their offset, and steps
+	// with the same offset are executed in the order specified.
+	OffsetMsec uint64 `protobuf:"varint,1,opt,name=offset_msec,json=offsetMsec,proto3" json:"offset_msec,omitempty"`
+	// The change made by the step, which must be specified.
+	//
+	// Types that are assignable to Action:
+	//	*ScenarioStep_SetInterface
+	//	*ScenarioStep_ClearInterface
+	//	*ScenarioStep_ClearAll
+	Action isScenarioStep_Action `protobuf_oneof:"action"`
+}
+
+func (x *ScenarioStep) Reset() {
+	*x = ScenarioStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScenarioStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScenarioStep) ProtoMessage() {}
+
+func (x *ScenarioStep) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScenarioStep.ProtoReflect.Descriptor instead.
+func (*ScenarioStep) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ScenarioStep) GetOffsetMsec() uint64 {
+	if x != nil {
+		return x.OffsetMsec
+	}
+	return 0
+}
+
+func (m *ScenarioStep) GetAction() isScenarioStep_Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (x *ScenarioStep) GetSetInterface() *SetInterfaceRequest {
+	if x, ok := x.GetAction().(*ScenarioStep_SetInterface); ok {
+		return x.SetInterface
+	}
+	return nil
+}
+
+func (x *ScenarioStep) GetClearInterface() *ClearInterfaceRequest {
+	if x, ok := x.GetAction().(*ScenarioStep_ClearInterface); ok {
+		return x.ClearInterface
+	}
+	return nil
+}
+
+func (x *ScenarioStep) GetClearAll() *ClearAllInterfaces {
+	if x, ok := x.GetAction().(*ScenarioStep_ClearAll); ok {
+		return x.ClearAll
+	}
+	return nil
+}
+
+type isScenarioStep_Action interface {
+	isScenarioStep_Action()
+}
+
+type ScenarioStep_SetInterface struct {
+	SetInterface *SetInterfaceRequest `protobuf:"bytes,2,opt,name=set_interface,json=setInterface,proto3,oneof"`
+}
+
+type ScenarioStep_ClearInterface struct {
+	ClearInterface *ClearInterfaceRequest `protobuf:"bytes,3,opt,name=clear_interface,json=clearInterface,proto3,oneof"`
+}
+
+type ScenarioStep_ClearAll struct {
+	ClearAll *ClearAllInterfaces `protobuf:"bytes,4,opt,name=clear_all,json=clearAll,proto3,oneof"`
+}
+
+func (*ScenarioStep_SetInterface) isScenarioStep_Action() {}
+
+func (*ScenarioStep_ClearInterface) isScenarioStep_Action() {}
+
+func (*ScenarioStep_ClearAll) isScenarioStep_Action() {}
+
+type RunScenarioRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The steps of the scenario, ordered by offset.
+	Steps []*ScenarioStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	// When set, each interface changed by the scenario is returned to the state
+	// that it was in before the scenario's first change to it once the
+	// scenario completes, fails or is cancelled.
+	Restore bool `protobuf:"varint,2,opt,name=restore,proto3" json:"restore,omitempty"`
+}
+
+func (x *RunScenarioRequest) Reset() {
+	*x = RunScenarioRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunScenarioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunScenarioRequest) ProtoMessage() {}
+
+func (x *RunScenarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunScenarioRequest.ProtoReflect.Descriptor instead.
+func (*RunScenarioRequest) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RunScenarioRequest) GetSteps() []*ScenarioStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *RunScenarioRequest) GetRestore() bool {
+	if x != nil {
+		return x.Restore
+	}
+	return false
+}
+
+// RunScenarioResponse reports that a step of a scenario has been applied.
+type RunScenarioResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Index of the step within the request, starting from 0.
+	Step uint32 `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	// Time at which the step was scheduled to be executed, and time at which it
+	// had been applied, expressed in nanoseconds since the Unix epoch.
+	ScheduledTimestamp int64 `protobuf:"varint,2,opt,name=scheduled_timestamp,json=scheduledTimestamp,proto3" json:"scheduled_timestamp,omitempty"`
+	Timestamp          int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *RunScenarioResponse) Reset() {
+	*x = RunScenarioResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aite_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunScenarioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunScenarioResponse) ProtoMessage() {}
+
+func (x *RunScenarioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aite_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunScenarioResponse.ProtoReflect.Descriptor instead.
+func (*RunScenarioResponse) Descriptor() ([]byte, []int) {
+	return file_aite_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RunScenarioResponse) GetStep() uint32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *RunScenarioResponse) GetScheduledTimestamp() int64 {
+	if x != nil {
+		return x.ScheduledTimestamp
+	}
+	return 0
+}
+
+func (x *RunScenarioResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+var File_aite_proto protoreflect.FileDescriptor
+
+var file_aite_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6f, 0x70,
+	0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x22, 0x82, 0x02,
+	0x0a, 0x13, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x19, 0x0a,
+	0x08, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x05, 0x66, 0x6c, 0x6f, 0x77,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x49, 0x6d,
+	0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x0e, 0x46, 0x6c, 0x6f, 0x77, 0x49, 0x6d, 0x70, 0x61, 0x69,
+	0x72, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3d, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0xdb, 0x01, 0x0a, 0x09, 0x46, 0x6c, 0x6f, 0x77, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x70, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x69, 0x70, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x72, 0x63, 0x5f, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x73, 0x74, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x72, 0x63, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x72, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x64, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x64, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x04, 0x64, 0x73, 0x63,
+	0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x04, 0x64, 0x73, 0x63, 0x70, 0x88,
+	0x01, 0x01, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x76, 0x6c, 0x61, 0x6e, 0x49, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x5f,
+	0x64, 0x73, 0x63, 0x70, 0x22, 0xbb, 0x07, 0x0a, 0x14, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x35, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x6d, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x73, 0x73, 0x5f,
+	0x70, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6c, 0x6f, 0x73, 0x73, 0x50,
+	0x63, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x73, 0x65,
+	0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d,
+	0x73, 0x65, 0x63, 0x12, 0x36, 0x0a, 0x17, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x63,
+	0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x63, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x6f, 0x72,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x63, 0x74, 0x12, 0x51, 0x0a, 0x12, 0x64,
+	0x65, 0x6c, 0x61, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x44,
+	0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x64, 0x65, 0x6c,
+	0x61, 0x79, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x63, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x50, 0x63, 0x74, 0x12, 0x3a, 0x0a, 0x19, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x63, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x63, 0x74, 0x12,
+	0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x5f, 0x70, 0x63, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x50, 0x63, 0x74,
+	0x12, 0x36, 0x0a, 0x17, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x63, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x15, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x63, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x5f, 0x70, 0x63, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x72,
+	0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x63, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x72, 0x65, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x70, 0x63, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x72, 0x65, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x63,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x6c, 0x6f, 0x73, 0x73, 0x50, 0x70, 0x6d, 0x12, 0x53, 0x0a, 0x0f,
+	0x67, 0x69, 0x6c, 0x62, 0x65, 0x72, 0x74, 0x5f, 0x65, 0x6c, 0x6c, 0x69, 0x6f, 0x74, 0x74, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x47, 0x69, 0x6c, 0x62, 0x65, 0x72, 0x74, 0x45,
+	0x6c, 0x6c, 0x69, 0x6f, 0x74, 0x74, 0x4c, 0x6f, 0x73, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x48,
+	0x00, 0x52, 0x0e, 0x67, 0x69, 0x6c, 0x62, 0x65, 0x72, 0x74, 0x45, 0x6c, 0x6c, 0x69, 0x6f, 0x74,
+	0x74, 0x12, 0x44, 0x0a, 0x0a, 0x66, 0x6f, 0x75, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x46, 0x6f, 0x75, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x4c, 0x6f, 0x73, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x09, 0x66, 0x6f,
+	0x75, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x61, 0x74, 0x65, 0x5f,
+	0x62, 0x70, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x61, 0x74, 0x65, 0x42,
+	0x70, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x68, 0x65, 0x61, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x12, 0x72, 0x61, 0x74, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72,
+	0x68, 0x65, 0x61, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x65, 0x6c,
+	0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x61,
+	0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
+	0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x22, 0x89, 0x01, 0x0a, 0x17, 0x47, 0x69, 0x6c, 0x62, 0x65, 0x72, 0x74, 0x45, 0x6c,
+	0x6c, 0x69, 0x6f, 0x74, 0x74, 0x4c, 0x6f, 0x73, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x13,
+	0x0a, 0x05, 0x70, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70,
+	0x50, 0x70, 0x6d, 0x12, 0x13, 0x0a, 0x05, 0x72, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x72, 0x50, 0x70, 0x6d, 0x12, 0x20, 0x0a, 0x0c, 0x62, 0x61, 0x64, 0x5f,
+	0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x62, 0x61, 0x64, 0x4c, 0x6f, 0x73, 0x73, 0x50, 0x70, 0x6d, 0x12, 0x22, 0x0a, 0x0d, 0x67, 0x6f,
+	0x6f, 0x64, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x67, 0x6f, 0x6f, 0x64, 0x4c, 0x6f, 0x73, 0x73, 0x50, 0x70, 0x6d, 0x22, 0x91,
+	0x01, 0x0a, 0x12, 0x46, 0x6f, 0x75, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x73, 0x73,
+	0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x31, 0x33, 0x5f, 0x70, 0x70, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x31, 0x33, 0x50, 0x70, 0x6d, 0x12, 0x17,
+	0x0a, 0x07, 0x70, 0x33, 0x31, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x70, 0x33, 0x31, 0x50, 0x70, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x33, 0x32, 0x5f, 0x70,
+	0x70, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x33, 0x32, 0x50, 0x70, 0x6d,
+	0x12, 0x17, 0x0a, 0x07, 0x70, 0x31, 0x34, 0x5f, 0x70, 0x70, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x70, 0x31, 0x34, 0x50, 0x70, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x32, 0x33,
+	0x5f, 0x70, 0x70, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x32, 0x33, 0x50,
+	0x70, 0x6d, 0x22, 0x83, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x3d, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74,
+	0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x22, 0x4b, 0x0a, 0x05, 0x51, 0x64, 0x69, 0x73,
+	0x63, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x89, 0x02, 0x0a, 0x09, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x61, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x74,
+	0x75, 0x12, 0x3c, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x39, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x72, 0x6f,
+	0x6f, 0x74, 0x5f, 0x71, 0x64, 0x69, 0x73, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65,
+	0x2e, 0x51, 0x64, 0x69, 0x73, 0x63, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x74, 0x51, 0x64, 0x69, 0x73,
+	0x63, 0x22, 0x17, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a, 0x16, 0x4c, 0x69,
+	0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x22, 0x29, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xc6, 0x01, 0x0a, 0x14,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x3d,
+	0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x35, 0x0a,
+	0x05, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x46,
+	0x6c, 0x6f, 0x77, 0x49, 0x6d, 0x70, 0x61, 0x69, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x66,
+	0x6c, 0x6f, 0x77, 0x73, 0x22, 0x2b, 0x0a, 0x15, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x52, 0x0a, 0x16, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x22, 0x76, 0x0a, 0x14, 0x46, 0x6c, 0x61, 0x70, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x70, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x75, 0x70, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x9e, 0x01,
+	0x0a, 0x15, 0x46, 0x6c, 0x61, 0x70, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x35,
+	0x0a, 0x10, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73,
+	0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x4d, 0x73, 0x65, 0x63, 0x22, 0x2e, 0x0a, 0x11, 0x4b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69,
+	0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x49, 0x64, 0x22, 0x5d, 0x0a, 0x19, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65,
+	0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x40, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x22, 0x61, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x09, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x2e, 0x0a, 0x16, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0xcf, 0x01, 0x0a, 0x17, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61,
+	0x69, 0x74, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x2c, 0x0a,
+	0x05, 0x71, 0x64, 0x69, 0x73, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x51,
+	0x64, 0x69, 0x73, 0x63, 0x52, 0x05, 0x71, 0x64, 0x69, 0x73, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x2e, 0x0a, 0x18, 0x47, 0x65, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xff, 0x01, 0x0a, 0x11, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x72, 0x78, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x74, 0x78, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x72, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x78, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x78, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x78, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x78, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x78, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x74, 0x78, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x74, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x8d, 0x02, 0x0a, 0x0a,
+	0x51, 0x64, 0x69, 0x73, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x71, 0x64, 0x69, 0x73, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61,
+	0x69, 0x74, 0x65, 0x2e, 0x51, 0x64, 0x69, 0x73, 0x63, 0x52, 0x05, 0x71, 0x64, 0x69, 0x73, 0x63,
+	0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6f, 0x76, 0x65, 0x72,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x6c,
+	0x6f, 0x67, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x71, 0x6c, 0x65, 0x6e, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x71, 0x6c, 0x65, 0x6e, 0x22, 0xca, 0x01, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x71, 0x64, 0x69, 0x73, 0x63, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x51, 0x64, 0x69, 0x73, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x06, 0x71, 0x64, 0x69, 0x73, 0x63, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x6c, 0x65, 0x61,
+	0x72, 0x41, 0x6c, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x22, 0x9d,
+	0x02, 0x0a, 0x0c, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x53, 0x74, 0x65, 0x70, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x4d, 0x73, 0x65, 0x63,
+	0x12, 0x4b, 0x0a, 0x0d, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0c, 0x73, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x51, 0x0a,
+	0x0f, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x12, 0x42, 0x0a, 0x09, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x5f, 0x61, 0x6c, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x41, 0x6c, 0x6c, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x48, 0x00, 0x52, 0x08, 0x63, 0x6c, 0x65, 0x61,
+	0x72, 0x41, 0x6c, 0x6c, 0x42, 0x08, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x63,
+	0x0a, 0x12, 0x52, 0x75, 0x6e, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x53, 0x74,
+	0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x22, 0x78, 0x0a, 0x13, 0x52, 0x75, 0x6e, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72,
+	0x69, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74,
+	0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x2f,
+	0x0a, 0x13, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2a, 0x54, 0x0a,
+	0x0e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x12, 0x0a, 0x0e, 0x49, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x53, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x11,
+	0x0a, 0x0d, 0x49, 0x53, 0x5f, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10,
+	0x02, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x53, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x5f, 0x44, 0x4f, 0x57,
+	0x4e, 0x10, 0x03, 0x2a, 0x3a, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x52, 0x5f, 0x45, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x00,
+	0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x49, 0x52, 0x5f, 0x49, 0x4e, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10,
+	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x52, 0x5f, 0x42, 0x4f, 0x54, 0x48, 0x10, 0x02, 0x2a,
+	0x56, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x44, 0x5f, 0x55, 0x4e, 0x49, 0x46, 0x4f,
+	0x52, 0x4d, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x44, 0x5f, 0x4e, 0x4f, 0x52, 0x4d, 0x41,
+	0x4c, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x44, 0x5f, 0x50, 0x41, 0x52, 0x45, 0x54, 0x4f,
+	0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x44, 0x5f, 0x50, 0x41, 0x52, 0x45, 0x54, 0x4f, 0x4e,
+	0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x03, 0x2a, 0x38, 0x0a, 0x0a, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x53, 0x5f,
+	0x55, 0x50, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x53, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10,
+	0x02, 0x2a, 0x84, 0x01, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x12, 0x0a, 0x0e, 0x4f, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x53, 0x5f, 0x55, 0x50, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x4f, 0x53, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x4f,
+	0x53, 0x5f, 0x4c, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x44, 0x4f,
+	0x57, 0x4e, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x53, 0x5f, 0x54, 0x45, 0x53, 0x54, 0x49,
+	0x4e, 0x47, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x53, 0x5f, 0x44, 0x4f, 0x52, 0x4d, 0x41,
+	0x4e, 0x54, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x4f, 0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x50,
+	0x52, 0x45, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x06, 0x2a, 0x95, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x54, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x54,
+	0x5f, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x45, 0x54, 0x5f, 0x4c, 0x49,
+	0x4e, 0x4b, 0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x54,
+	0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x03, 0x12,
+	0x13, 0x0a, 0x0f, 0x45, 0x54, 0x5f, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56,
+	0x45, 0x44, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x45, 0x54, 0x5f, 0x51, 0x44, 0x49, 0x53, 0x43,
+	0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x45, 0x54,
+	0x5f, 0x51, 0x44, 0x49, 0x53, 0x43, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x06,
+	0x32, 0xdf, 0x07, 0x0a, 0x04, 0x41, 0x69, 0x74, 0x65, 0x12, 0x5b, 0x0a, 0x0c, 0x53, 0x65, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x24, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x74, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74,
+	0x65, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x27, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69,
+	0x74, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x24, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x26, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x27, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69,
+	0x74, 0x65, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0d, 0x46, 0x6c, 0x61,
+	0x70, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x25, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x46, 0x6c, 0x61,
+	0x70, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x26, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61,
+	0x69, 0x74, 0x65, 0x2e, 0x46, 0x6c, 0x61, 0x70, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x09, 0x4b,
+	0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x21, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x41,
+	0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6f, 0x70,
+	0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x4b, 0x65,
+	0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28,
+	0x01, 0x30, 0x01, 0x12, 0x6d, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x2a, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x66, 0x0a, 0x0f, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x27, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28,
+	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x6a, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x29, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x47, 0x65, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x53, 0x63, 0x65,
+	0x6e, 0x61, 0x72, 0x69, 0x6f, 0x12, 0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x52, 0x75, 0x6e, 0x53, 0x63, 0x65, 0x6e, 0x61,
+	0x72, 0x69, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x61, 0x69, 0x74, 0x65, 0x2e, 0x52, 0x75, 0x6e,
+	0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x61, 0x69, 0x74, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x69, 0x74, 0x65, 0x3b, 0x61, 0x69, 0x74, 0x65,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aite_proto_rawDescOnce sync.Once
+	file_aite_proto_rawDescData = file_aite_proto_rawDesc
+)
+
+func file_aite_proto_rawDescGZIP() []byte {
+	file_aite_proto_rawDescOnce.Do(func() {
+		file_aite_proto_rawDescData = protoimpl.X.CompressGZIP(file_aite_proto_rawDescData)
+	})
+	return file_aite_proto_rawDescData
+}
+
+var file_aite_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_aite_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_aite_proto_goTypes = []interface{}{
+	(InterfaceState)(0),                // 0: openconfig.aite.InterfaceState
+	(Direction)(0),                     // 1: openconfig.aite.Direction
+	(DelayDistribution)(0),             // 2: openconfig.aite.DelayDistribution
+	(AdminState)(0),                    // 3: openconfig.aite.AdminState
+	(OperState)(0),                     // 4: openconfig.aite.OperState
+	(EventType)(0),                     // 5: openconfig.aite.EventType
+	(*SetInterfaceRequest)(nil),        // 6: openconfig.aite.SetInterfaceRequest
+	(*FlowImpairment)(nil),             // 7: openconfig.aite.FlowImpairment
+	(*FlowMatch)(nil),                  // 8: openconfig.aite.FlowMatch
+	(*InterfaceStateParams)(nil),       // 9: openconfig.aite.InterfaceStateParams
+	(*GilbertElliottLossModel)(nil),    // 10: openconfig.aite.GilbertElliottLossModel
+	(*FourStateLossModel)(nil),         // 11: openconfig.aite.FourStateLossModel
+	(*SetInterfaceResponse)(nil),       // 12: openconfig.aite.SetInterfaceResponse
+	(*Qdisc)(nil),                      // 13: openconfig.aite.Qdisc
+	(*Interface)(nil),                  // 14: openconfig.aite.Interface
+	(*ListInterfacesRequest)(nil),      // 15: openconfig.aite.ListInterfacesRequest
+	(*ListInterfacesResponse)(nil),     // 16: openconfig.aite.ListInterfacesResponse
+	(*GetInterfaceRequest)(nil),        // 17: openconfig.aite.GetInterfaceRequest
+	(*GetInterfaceResponse)(nil),       // 18: openconfig.aite.GetInterfaceResponse
+	(*ClearInterfaceRequest)(nil),      // 19: openconfig.aite.ClearInterfaceRequest
+	(*ClearInterfaceResponse)(nil),     // 20: openconfig.aite.ClearInterfaceResponse
+	(*FlapInterfaceRequest)(nil),       // 21: openconfig.aite.FlapInterfaceRequest
+	(*FlapInterfaceResponse)(nil),      // 22: openconfig.aite.FlapInterfaceResponse
+	(*KeepAliveRequest)(nil),           // 23: openconfig.aite.KeepAliveRequest
+	(*KeepAliveResponse)(nil),          // 24: openconfig.aite.KeepAliveResponse
+	(*BatchSetInterfacesRequest)(nil),  // 25: openconfig.aite.BatchSetInterfacesRequest
+	(*BatchSetInterfacesResponse)(nil), // 26: openconfig.aite.BatchSetInterfacesResponse
+	(*WatchInterfacesRequest)(nil),     // 27: openconfig.aite.WatchInterfacesRequest
+	(*WatchInterfacesResponse)(nil),    // 28: openconfig.aite.WatchInterfacesResponse
+	(*GetInterfaceStatsRequest)(nil),   // 29: openconfig.aite.GetInterfaceStatsRequest
+	(*InterfaceCounters)(nil),          // 30: openconfig.aite.InterfaceCounters
+	(*QdiscStats)(nil),                 // 31: openconfig.aite.QdiscStats
+	(*GetInterfaceStatsResponse)(nil),  // 32: openconfig.aite.GetInterfaceStatsResponse
+	(*ClearAllInterfaces)(nil),         // 33: openconfig.aite.ClearAllInterfaces
+	(*ScenarioStep)(nil),               // 34: openconfig.aite.ScenarioStep
+	(*RunScenarioRequest)(nil),         // 35: openconfig.aite.RunScenarioRequest
+	(*RunScenarioResponse)(nil),        // 36: openconfig.aite.RunScenarioResponse
+}
+var file_aite_proto_depIdxs = []int32{
+	9,  // 0: openconfig.aite.SetInterfaceRequest.params:type_name -> openconfig.aite.InterfaceStateParams
+	7,  // 1: openconfig.aite.SetInterfaceRequest.flows:type_name -> openconfig.aite.FlowImpairment
+	8,  // 2: openconfig.aite.FlowImpairment.match:type_name -> openconfig.aite.FlowMatch
+	9,  // 3: openconfig.aite.FlowImpairment.params:type_name -> openconfig.aite.InterfaceStateParams
+	0,  // 4: openconfig.aite.InterfaceStateParams.state:type_name -> openconfig.aite.InterfaceState
+	2,  // 5: openconfig.aite.InterfaceStateParams.delay_distribution:type_name -> openconfig.aite.DelayDistribution
+	10, // 6: openconfig.aite.InterfaceStateParams.gilbert_elliott:type_name -> openconfig.aite.GilbertElliottLossModel
+	11, // 7: openconfig.aite.InterfaceStateParams.four_state:type_name -> openconfig.aite.FourStateLossModel
+	1,  // 8: openconfig.aite.InterfaceStateParams.direction:type_name -> openconfig.aite.Direction
+	9,  // 9: openconfig.aite.SetInterfaceResponse.params:type_name -> openconfig.aite.InterfaceStateParams
+	3,  // 10: openconfig.aite.Interface.admin_state:type_name -> openconfig.aite.AdminState
+	4,  // 11: openconfig.aite.Interface.oper_state:type_name -> openconfig.aite.OperState
+	13, // 12: openconfig.aite.Interface.root_qdisc:type_name -> openconfig.aite.Qdisc
+	14, // 13: openconfig.aite.ListInterfacesResponse.interfaces:type_name -> openconfig.aite.Interface
+	14, // 14: openconfig.aite.GetInterfaceResponse.interface:type_name -> openconfig.aite.Interface
+	9,  // 15: openconfig.aite.GetInterfaceResponse.params:type_name -> openconfig.aite.InterfaceStateParams
+	7,  // 16: openconfig.aite.GetInterfaceResponse.flows:type_name -> openconfig.aite.FlowImpairment
+	14, // 17: openconfig.aite.ClearInterfaceResponse.interface:type_name -> openconfig.aite.Interface
+	0,  // 18: openconfig.aite.FlapInterfaceResponse.state:type_name -> openconfig.aite.InterfaceState
+	6,  // 19: openconfig.aite.BatchSetInterfacesRequest.requests:type_name -> openconfig.aite.SetInterfaceRequest
+	12, // 20: openconfig.aite.BatchSetInterfacesResponse.responses:type_name -> openconfig.aite.SetInterfaceResponse
+	5,  // 21: openconfig.aite.WatchInterfacesResponse.type:type_name -> openconfig.aite.EventType
+	14, // 22: openconfig.aite.WatchInterfacesResponse.interface:type_name -> openconfig.aite.Interface
+	13, // 23: openconfig.aite.WatchInterfacesResponse.qdisc:type_name -> openconfig.aite.Qdisc
+	13, // 24: openconfig.aite.QdiscStats.qdisc:type_name -> openconfig.aite.Qdisc
+	14, // 25: openconfig.aite.GetInterfaceStatsResponse.interface:type_name -> openconfig.aite.Interface
+	30, // 26: openconfig.aite.GetInterfaceStatsResponse.counters:type_name -> openconfig.aite.InterfaceCounters
+	31, // 27: openconfig.aite.GetInterfaceStatsResponse.qdiscs:type_name -> openconfig.aite.QdiscStats
+	6,  // 28: openconfig.aite.ScenarioStep.set_interface:type_name -> openconfig.aite.SetInterfaceRequest
+	19, // 29: openconfig.aite.ScenarioStep.clear_interface:type_name -> openconfig.aite.ClearInterfaceRequest
+	33, // 30: openconfig.aite.ScenarioStep.clear_all:type_name -> openconfig.aite.ClearAllInterfaces
+	34, // 31: openconfig.aite.RunScenarioRequest.steps:type_name -> openconfig.aite.ScenarioStep
+	6,  // 32: openconfig.aite.Aite.SetInterface:input_type -> openconfig.aite.SetInterfaceRequest
+	15, // 33: openconfig.aite.Aite.ListInterfaces:input_type -> openconfig.aite.ListInterfacesRequest
+	17, // 34: openconfig.aite.Aite.GetInterface:input_type -> openconfig.aite.GetInterfaceRequest
+	19, // 35: openconfig.aite.Aite.ClearInterface:input_type -> openconfig.aite.ClearInterfaceRequest
+	21, // 36: openconfig.aite.Aite.FlapInterface:input_type -> openconfig.aite.FlapInterfaceRequest
+	23, // 37: openconfig.aite.Aite.KeepAlive:input_type -> openconfig.aite.KeepAliveRequest
+	25, // 38: openconfig.aite.Aite.BatchSetInterfaces:input_type -> openconfig.aite.BatchSetInterfacesRequest
+	27, // 39: openconfig.aite.Aite.WatchInterfaces:input_type -> openconfig.aite.WatchInterfacesRequest
+	29, // 40: openconfig.aite.Aite.GetInterfaceStats:input_type -> openconfig.aite.GetInterfaceStatsRequest
+	35, // 41: openconfig.aite.Aite.RunScenario:input_type -> openconfig.aite.RunScenarioRequest
+	12, // 42: openconfig.aite.Aite.SetInterface:output_type -> openconfig.aite.SetInterfaceResponse
+	16, // 43: openconfig.aite.Aite.ListInterfaces:output_type -> openconfig.aite.ListInterfacesResponse
+	18, // 44: openconfig.aite.Aite.GetInterface:output_type -> openconfig.aite.GetInterfaceResponse
+	20, // 45: openconfig.aite.Aite.ClearInterface:output_type -> openconfig.aite.ClearInterfaceResponse
+	22, // 46: openconfig.aite.Aite.FlapInterface:output_type -> openconfig.aite.FlapInterfaceResponse
+	24, // 47: openconfig.aite.Aite.KeepAlive:output_type -> openconfig.aite.KeepAliveResponse
+	26, // 48: openconfig.aite.Aite.BatchSetInterfaces:output_type -> openconfig.aite.BatchSetInterfacesResponse
+	28, // 49: openconfig.aite.Aite.WatchInterfaces:output_type -> openconfig.aite.WatchInterfacesResponse
+	32, // 50: openconfig.aite.Aite.GetInterfaceStats:output_type -> openconfig.aite.GetInterfaceStatsResponse
+	36, // 51: openconfig.aite.Aite.RunScenario:output_type -> openconfig.aite.RunScenarioResponse
+	42, // [42:52] is the sub-list for method output_type
+	32, // [32:42] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
+}
+
+func init() { file_aite_proto_init() }
+func file_aite_proto_init() {
+	if File_aite_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_aite_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetInterfaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlowImpairment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlowMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InterfaceStateParams); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -356,8 +3345,8 @@ func file_aite_proto_init() {
 				return nil
 			}
 		}
-		file_aite_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InterfaceStateParams); i {
+		file_aite_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GilbertElliottLossModel); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -368,7 +3357,19 @@ func file_aite_proto_init() {
 				return nil
 			}
 		}
-		file_aite_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_aite_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FourStateLossModel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SetInterfaceResponse); i {
 			case 0:
 				return &v.state
@@ -380,14 +3381,312 @@ func file_aite_proto_init() {
 				return nil
 			}
 		}
+		file_aite_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Qdisc); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Interface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListInterfacesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListInterfacesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInterfaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInterfaceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearInterfaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearInterfaceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlapInterfaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlapInterfaceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeepAliveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeepAliveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSetInterfacesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSetInterfacesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchInterfacesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchInterfacesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInterfaceStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InterfaceCounters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QdiscStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInterfaceStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearAllInterfaces); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScenarioStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunScenarioRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aite_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunScenarioResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_aite_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_aite_proto_msgTypes[3].OneofWrappers = []interface{}{
+		(*InterfaceStateParams_GilbertElliott)(nil),
+		(*InterfaceStateParams_FourState)(nil),
+	}
+	file_aite_proto_msgTypes[28].OneofWrappers = []interface{}{
+		(*ScenarioStep_SetInterface)(nil),
+		(*ScenarioStep_ClearInterface)(nil),
+		(*ScenarioStep_ClearAll)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_aite_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      6,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
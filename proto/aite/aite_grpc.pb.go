@@ -7,7 +7,8 @@
 // Package aite implements a disruptive service which can manipulate resources
 // within a KNE pod.
 //
-// It is named after Aite (Até) the Greek goddess of mischief, delusion and ruin.
+// It is named after Aite (Até) the Greek goddess of mischief, delusion
+// and ruin.
 
 package aite
 
@@ -24,7 +25,16 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Aite_SetInterface_FullMethodName = "/openconfig.aite.Aite/SetInterface"
+	Aite_SetInterface_FullMethodName       = "/openconfig.aite.Aite/SetInterface"
+	Aite_ListInterfaces_FullMethodName     = "/openconfig.aite.Aite/ListInterfaces"
+	Aite_GetInterface_FullMethodName       = "/openconfig.aite.Aite/GetInterface"
+	Aite_ClearInterface_FullMethodName     = "/openconfig.aite.Aite/ClearInterface"
+	Aite_FlapInterface_FullMethodName      = "/openconfig.aite.Aite/FlapInterface"
+	Aite_KeepAlive_FullMethodName          = "/openconfig.aite.Aite/KeepAlive"
+	Aite_BatchSetInterfaces_FullMethodName = "/openconfig.aite.Aite/BatchSetInterfaces"
+	Aite_WatchInterfaces_FullMethodName    = "/openconfig.aite.Aite/WatchInterfaces"
+	Aite_GetInterfaceStats_FullMethodName  = "/openconfig.aite.Aite/GetInterfaceStats"
+	Aite_RunScenario_FullMethodName        = "/openconfig.aite.Aite/RunScenario"
 )
 
 // AiteClient is the client API for Aite service.
@@ -33,6 +43,55 @@ const (
 type AiteClient interface {
 	// SetInterface changes the state of an interface within the target pod.
 	SetInterface(ctx context.Context, in *SetInterfaceRequest, opts ...grpc.CallOption) (*SetInterfaceResponse, error)
+	// ListInterfaces returns the interfaces that exist within the target pod
+	// such that interface discovery can be performed.
+	ListInterfaces(ctx context.Context, in *ListInterfacesRequest, opts ...grpc.CallOption) (*ListInterfacesResponse, error)
+	// GetInterface returns the state of an interface within the target pod,
+	// including the impairments that are currently programmed in the kernel.
+	GetInterface(ctx context.Context, in *GetInterfaceRequest, opts ...grpc.CallOption) (*GetInterfaceResponse, error)
+	// ClearInterface removes any impairments that have been applied to an
+	// interface, restoring the qdisc that was installed before Aite first
+	// manipulated it, and the carrier of the interface if Aite removed it.
+	// Only the root qdisc itself is restored: the classes, child qdiscs and
+	// filters of a classful root qdisc are not, and must be reinstalled by the
+	// caller.
+	ClearInterface(ctx context.Context, in *ClearInterfaceRequest, opts ...grpc.CallOption) (*ClearInterfaceResponse, error)
+	// FlapInterface repeatedly brings an interface administratively down and
+	// back up according to the specified schedule, streaming each transition
+	// to the caller as it occurs. When the schedule completes or the RPC is
+	// cancelled, the interface is returned to the administrative state that it
+	// was in before the flap. Any pending revert of the interface is cancelled,
+	// and the interface is released from any lease under which it was changed.
+	// Requests to change the state of the interface are rejected whilst it is
+	// being flapped.
+	FlapInterface(ctx context.Context, in *FlapInterfaceRequest, opts ...grpc.CallOption) (Aite_FlapInterfaceClient, error)
+	// KeepAlive establishes a lease that is held for as long as the client
+	// continues to send heartbeats on the stream. When the stream is closed, or
+	// a heartbeat is not received within the lease's timeout, every change made
+	// to interfaces under the lease is rolled back.
+	KeepAlive(ctx context.Context, opts ...grpc.CallOption) (Aite_KeepAliveClient, error)
+	// BatchSetInterfaces changes the state of several interfaces within the
+	// target pod together. All requests are validated before any interface is
+	// changed, and if any interface cannot be changed, the interfaces that have
+	// already been changed by the batch are returned to their prior state, and
+	// to any lease under which they were previously changed.
+	BatchSetInterfaces(ctx context.Context, in *BatchSetInterfacesRequest, opts ...grpc.CallOption) (*BatchSetInterfacesResponse, error)
+	// WatchInterfaces streams an event each time that an interface within the
+	// target pod is added or removed, changes its administrative state,
+	// operational state or MTU, or has a qdisc installed, changed or removed.
+	// Events are driven by notifications from the kernel, and hence reflect
+	// changes made by Aite as well as those made by other processes.
+	WatchInterfaces(ctx context.Context, in *WatchInterfacesRequest, opts ...grpc.CallOption) (Aite_WatchInterfacesClient, error)
+	// GetInterfaceStats returns the counters of an interface within the target
+	// pod, along with the statistics of each qdisc that is installed on it, and
+	// on the device to which its ingress traffic is redirected, such that the
+	// effect of impairments can be verified.
+	GetInterfaceStats(ctx context.Context, in *GetInterfaceStatsRequest, opts ...grpc.CallOption) (*GetInterfaceStatsResponse, error)
+	// RunScenario executes a timeline of changes to interfaces within the
+	// target pod, each at a specified offset from the start of the scenario,
+	// streaming each step to the caller once it has been applied. Cancelling
+	// the RPC stops the scenario before its next step.
+	RunScenario(ctx context.Context, in *RunScenarioRequest, opts ...grpc.CallOption) (Aite_RunScenarioClient, error)
 }
 
 type aiteClient struct {
@@ -52,12 +111,233 @@ func (c *aiteClient) SetInterface(ctx context.Context, in *SetInterfaceRequest,
 	return out, nil
 }
 
+func (c *aiteClient) ListInterfaces(ctx context.Context, in *ListInterfacesRequest, opts ...grpc.CallOption) (*ListInterfacesResponse, error) {
+	out := new(ListInterfacesResponse)
+	err := c.cc.Invoke(ctx, Aite_ListInterfaces_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiteClient) GetInterface(ctx context.Context, in *GetInterfaceRequest, opts ...grpc.CallOption) (*GetInterfaceResponse, error) {
+	out := new(GetInterfaceResponse)
+	err := c.cc.Invoke(ctx, Aite_GetInterface_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiteClient) ClearInterface(ctx context.Context, in *ClearInterfaceRequest, opts ...grpc.CallOption) (*ClearInterfaceResponse, error) {
+	out := new(ClearInterfaceResponse)
+	err := c.cc.Invoke(ctx, Aite_ClearInterface_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiteClient) FlapInterface(ctx context.Context, in *FlapInterfaceRequest, opts ...grpc.CallOption) (Aite_FlapInterfaceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Aite_ServiceDesc.Streams[0], Aite_FlapInterface_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aiteFlapInterfaceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Aite_FlapInterfaceClient interface {
+	Recv() (*FlapInterfaceResponse, error)
+	grpc.ClientStream
+}
+
+type aiteFlapInterfaceClient struct {
+	grpc.ClientStream
+}
+
+func (x *aiteFlapInterfaceClient) Recv() (*FlapInterfaceResponse, error) {
+	m := new(FlapInterfaceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aiteClient) KeepAlive(ctx context.Context, opts ...grpc.CallOption) (Aite_KeepAliveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Aite_ServiceDesc.Streams[1], Aite_KeepAlive_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aiteKeepAliveClient{stream}
+	return x, nil
+}
+
+type Aite_KeepAliveClient interface {
+	Send(*KeepAliveRequest) error
+	Recv() (*KeepAliveResponse, error)
+	grpc.ClientStream
+}
+
+type aiteKeepAliveClient struct {
+	grpc.ClientStream
+}
+
+func (x *aiteKeepAliveClient) Send(m *KeepAliveRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aiteKeepAliveClient) Recv() (*KeepAliveResponse, error) {
+	m := new(KeepAliveResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aiteClient) BatchSetInterfaces(ctx context.Context, in *BatchSetInterfacesRequest, opts ...grpc.CallOption) (*BatchSetInterfacesResponse, error) {
+	out := new(BatchSetInterfacesResponse)
+	err := c.cc.Invoke(ctx, Aite_BatchSetInterfaces_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiteClient) WatchInterfaces(ctx context.Context, in *WatchInterfacesRequest, opts ...grpc.CallOption) (Aite_WatchInterfacesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Aite_ServiceDesc.Streams[2], Aite_WatchInterfaces_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aiteWatchInterfacesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Aite_WatchInterfacesClient interface {
+	Recv() (*WatchInterfacesResponse, error)
+	grpc.ClientStream
+}
+
+type aiteWatchInterfacesClient struct {
+	grpc.ClientStream
+}
+
+func (x *aiteWatchInterfacesClient) Recv() (*WatchInterfacesResponse, error) {
+	m := new(WatchInterfacesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aiteClient) GetInterfaceStats(ctx context.Context, in *GetInterfaceStatsRequest, opts ...grpc.CallOption) (*GetInterfaceStatsResponse, error) {
+	out := new(GetInterfaceStatsResponse)
+	err := c.cc.Invoke(ctx, Aite_GetInterfaceStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiteClient) RunScenario(ctx context.Context, in *RunScenarioRequest, opts ...grpc.CallOption) (Aite_RunScenarioClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Aite_ServiceDesc.Streams[3], Aite_RunScenario_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aiteRunScenarioClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Aite_RunScenarioClient interface {
+	Recv() (*RunScenarioResponse, error)
+	grpc.ClientStream
+}
+
+type aiteRunScenarioClient struct {
+	grpc.ClientStream
+}
+
+func (x *aiteRunScenarioClient) Recv() (*RunScenarioResponse, error) {
+	m := new(RunScenarioResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AiteServer is the server API for Aite service.
 // All implementations must embed UnimplementedAiteServer
 // for forward compatibility
 type AiteServer interface {
 	// SetInterface changes the state of an interface within the target pod.
 	SetInterface(context.Context, *SetInterfaceRequest) (*SetInterfaceResponse, error)
+	// ListInterfaces returns the interfaces that exist within the target pod
+	// such that interface discovery can be performed.
+	ListInterfaces(context.Context, *ListInterfacesRequest) (*ListInterfacesResponse, error)
+	// GetInterface returns the state of an interface within the target pod,
+	// including the impairments that are currently programmed in the kernel.
+	GetInterface(context.Context, *GetInterfaceRequest) (*GetInterfaceResponse, error)
+	// ClearInterface removes any impairments that have been applied to an
+	// interface, restoring the qdisc that was installed before Aite first
+	// manipulated it, and the carrier of the interface if Aite removed it.
+	// Only the root qdisc itself is restored: the classes, child qdiscs and
+	// filters of a classful root qdisc are not, and must be reinstalled by the
+	// caller.
+	ClearInterface(context.Context, *ClearInterfaceRequest) (*ClearInterfaceResponse, error)
+	// FlapInterface repeatedly brings an interface administratively down and
+	// back up according to the specified schedule, streaming each transition
+	// to the caller as it occurs. When the schedule completes or the RPC is
+	// cancelled, the interface is returned to the administrative state that it
+	// was in before the flap. Any pending revert of the interface is cancelled,
+	// and the interface is released from any lease under which it was changed.
+	// Requests to change the state of the interface are rejected whilst it is
+	// being flapped.
+	FlapInterface(*FlapInterfaceRequest, Aite_FlapInterfaceServer) error
+	// KeepAlive establishes a lease that is held for as long as the client
+	// continues to send heartbeats on the stream. When the stream is closed, or
+	// a heartbeat is not received within the lease's timeout, every change made
+	// to interfaces under the lease is rolled back.
+	KeepAlive(Aite_KeepAliveServer) error
+	// BatchSetInterfaces changes the state of several interfaces within the
+	// target pod together. All requests are validated before any interface is
+	// changed, and if any interface cannot be changed, the interfaces that have
+	// already been changed by the batch are returned to their prior state, and
+	// to any lease under which they were previously changed.
+	BatchSetInterfaces(context.Context, *BatchSetInterfacesRequest) (*BatchSetInterfacesResponse, error)
+	// WatchInterfaces streams an event each time that an interface within the
+	// target pod is added or removed, changes its administrative state,
+	// operational state or MTU, or has a qdisc installed, changed or removed.
+	// Events are driven by notifications from the kernel, and hence reflect
+	// changes made by Aite as well as those made by other processes.
+	WatchInterfaces(*WatchInterfacesRequest, Aite_WatchInterfacesServer) error
+	// GetInterfaceStats returns the counters of an interface within the target
+	// pod, along with the statistics of each qdisc that is installed on it, and
+	// on the device to which its ingress traffic is redirected, such that the
+	// effect of impairments can be verified.
+	GetInterfaceStats(context.Context, *GetInterfaceStatsRequest) (*GetInterfaceStatsResponse, error)
+	// RunScenario executes a timeline of changes to interfaces within the
+	// target pod, each at a specified offset from the start of the scenario,
+	// streaming each step to the caller once it has been applied. Cancelling
+	// the RPC stops the scenario before its next step.
+	RunScenario(*RunScenarioRequest, Aite_RunScenarioServer) error
 	mustEmbedUnimplementedAiteServer()
 }
 
@@ -68,6 +348,33 @@ type UnimplementedAiteServer struct {
 func (UnimplementedAiteServer) SetInterface(context.Context, *SetInterfaceRequest) (*SetInterfaceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetInterface not implemented")
 }
+func (UnimplementedAiteServer) ListInterfaces(context.Context, *ListInterfacesRequest) (*ListInterfacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInterfaces not implemented")
+}
+func (UnimplementedAiteServer) GetInterface(context.Context, *GetInterfaceRequest) (*GetInterfaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInterface not implemented")
+}
+func (UnimplementedAiteServer) ClearInterface(context.Context, *ClearInterfaceRequest) (*ClearInterfaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearInterface not implemented")
+}
+func (UnimplementedAiteServer) FlapInterface(*FlapInterfaceRequest, Aite_FlapInterfaceServer) error {
+	return status.Errorf(codes.Unimplemented, "method FlapInterface not implemented")
+}
+func (UnimplementedAiteServer) KeepAlive(Aite_KeepAliveServer) error {
+	return status.Errorf(codes.Unimplemented, "method KeepAlive not implemented")
+}
+func (UnimplementedAiteServer) BatchSetInterfaces(context.Context, *BatchSetInterfacesRequest) (*BatchSetInterfacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSetInterfaces not implemented")
+}
+func (UnimplementedAiteServer) WatchInterfaces(*WatchInterfacesRequest, Aite_WatchInterfacesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInterfaces not implemented")
+}
+func (UnimplementedAiteServer) GetInterfaceStats(context.Context, *GetInterfaceStatsRequest) (*GetInterfaceStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInterfaceStats not implemented")
+}
+func (UnimplementedAiteServer) RunScenario(*RunScenarioRequest, Aite_RunScenarioServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunScenario not implemented")
+}
 func (UnimplementedAiteServer) mustEmbedUnimplementedAiteServer() {}
 
 // UnsafeAiteServer may be embedded to opt out of forward compatibility for this service.
@@ -99,6 +406,185 @@ func _Aite_SetInterface_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Aite_ListInterfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInterfacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiteServer).ListInterfaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Aite_ListInterfaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiteServer).ListInterfaces(ctx, req.(*ListInterfacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Aite_GetInterface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInterfaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiteServer).GetInterface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Aite_GetInterface_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiteServer).GetInterface(ctx, req.(*GetInterfaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Aite_ClearInterface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearInterfaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiteServer).ClearInterface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Aite_ClearInterface_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiteServer).ClearInterface(ctx, req.(*ClearInterfaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Aite_FlapInterface_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FlapInterfaceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AiteServer).FlapInterface(m, &aiteFlapInterfaceServer{stream})
+}
+
+type Aite_FlapInterfaceServer interface {
+	Send(*FlapInterfaceResponse) error
+	grpc.ServerStream
+}
+
+type aiteFlapInterfaceServer struct {
+	grpc.ServerStream
+}
+
+func (x *aiteFlapInterfaceServer) Send(m *FlapInterfaceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Aite_KeepAlive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AiteServer).KeepAlive(&aiteKeepAliveServer{stream})
+}
+
+type Aite_KeepAliveServer interface {
+	Send(*KeepAliveResponse) error
+	Recv() (*KeepAliveRequest, error)
+	grpc.ServerStream
+}
+
+type aiteKeepAliveServer struct {
+	grpc.ServerStream
+}
+
+func (x *aiteKeepAliveServer) Send(m *KeepAliveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aiteKeepAliveServer) Recv() (*KeepAliveRequest, error) {
+	m := new(KeepAliveRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Aite_BatchSetInterfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSetInterfacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiteServer).BatchSetInterfaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Aite_BatchSetInterfaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiteServer).BatchSetInterfaces(ctx, req.(*BatchSetInterfacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Aite_WatchInterfaces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInterfacesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AiteServer).WatchInterfaces(m, &aiteWatchInterfacesServer{stream})
+}
+
+type Aite_WatchInterfacesServer interface {
+	Send(*WatchInterfacesResponse) error
+	grpc.ServerStream
+}
+
+type aiteWatchInterfacesServer struct {
+	grpc.ServerStream
+}
+
+func (x *aiteWatchInterfacesServer) Send(m *WatchInterfacesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Aite_GetInterfaceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInterfaceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiteServer).GetInterfaceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Aite_GetInterfaceStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiteServer).GetInterfaceStats(ctx, req.(*GetInterfaceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Aite_RunScenario_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunScenarioRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AiteServer).RunScenario(m, &aiteRunScenarioServer{stream})
+}
+
+type Aite_RunScenarioServer interface {
+	Send(*RunScenarioResponse) error
+	grpc.ServerStream
+}
+
+type aiteRunScenarioServer struct {
+	grpc.ServerStream
+}
+
+func (x *aiteRunScenarioServer) Send(m *RunScenarioResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Aite_ServiceDesc is the grpc.ServiceDesc for Aite service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -110,7 +596,49 @@ var Aite_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetInterface",
 			Handler:    _Aite_SetInterface_Handler,
 		},
+		{
+			MethodName: "ListInterfaces",
+			Handler:    _Aite_ListInterfaces_Handler,
+		},
+		{
+			MethodName: "GetInterface",
+			Handler:    _Aite_GetInterface_Handler,
+		},
+		{
+			MethodName: "ClearInterface",
+			Handler:    _Aite_ClearInterface_Handler,
+		},
+		{
+			MethodName: "BatchSetInterfaces",
+			Handler:    _Aite_BatchSetInterfaces_Handler,
+		},
+		{
+			MethodName: "GetInterfaceStats",
+			Handler:    _Aite_GetInterfaceStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FlapInterface",
+			Handler:       _Aite_FlapInterface_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "KeepAlive",
+			Handler:       _Aite_KeepAlive_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchInterfaces",
+			Handler:       _Aite_WatchInterfaces_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RunScenario",
+			Handler:       _Aite_RunScenario_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "aite.proto",
 }
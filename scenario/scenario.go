@@ -0,0 +1,234 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scenario loads Aite scenarios from files, such that impairment timelines
+// can be described declaratively rather than constructed in code. A scenario file
+// is the protojson encoding of a RunScenarioRequest, written either as JSON or as
+// the equivalent YAML, for example:
+//
+//	restore: true
+//	steps:
+//	  - offsetMsec: 0
+//	    setInterface:
+//	      name: eth1
+//	      params:
+//	        state: IS_UP
+//	        latencyMsec: 50
+//	  - offsetMsec: 10000
+//	    setInterface:
+//	      name: eth2
+//	      params:
+//	        state: IS_UP
+//	        lossPct: 100
+//	  - offsetMsec: 30000
+//	    clearAll: {}
+//
+// Field names may be written either in lowerCamelCase or as in the proto, and enum
+// values are written as their names. Unknown fields are rejected, such that typos
+// are not silently ignored.
+//
+// The package also loads files of named impairment profiles, which map each name
+// to the protojson encoding of an InterfaceStateParams, for example:
+//
+//	satellite:
+//	  state: IS_UP
+//	  latencyMsec: 600
+//	  jitterMsec: 20
+//	  lossPct: 1
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv"
+)
+
+// Format is the encoding of a scenario file.
+type Format int
+
+const (
+	// YAML indicates that the scenario is encoded as YAML.
+	YAML Format = iota
+	// JSON indicates that the scenario is encoded as protojson.
+	JSON
+)
+
+// String returns the name of the format f.
+func (f Format) String() string {
+	switch f {
+	case YAML:
+		return "YAML"
+	case JSON:
+		return "JSON"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// formatOf returns the format of the file at the specified path, as determined by
+// its extension. Files with the extension .json are JSON, and all others YAML.
+func formatOf(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return JSON
+	}
+	return YAML
+}
+
+// toJSON returns the JSON equivalent of b, which is encoded in the format f.
+func toJSON(b []byte, f Format) ([]byte, error) {
+	switch f {
+	case YAML:
+		j, err := yaml.YAMLToJSON(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML, %v", err)
+		}
+		return j, nil
+	case JSON:
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown format %s", f)
+	}
+}
+
+// Load reads the scenario file at the specified path, determining its format from
+// the file's extension. Files with the extension .json are parsed as JSON, and all
+// others as YAML.
+func Load(path string) (*apb.RunScenarioRequest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read scenario file, %v", err)
+	}
+
+	s, err := Parse(b, formatOf(path))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse scenario file %s, %v", path, err)
+	}
+	return s, nil
+}
+
+// Parse parses the scenario b, which is encoded in the format f.
+func Parse(b []byte, f Format) (*apb.RunScenarioRequest, error) {
+	j, err := toJSON(b, f)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &apb.RunScenarioRequest{}
+	if err := protojson.Unmarshal(j, s); err != nil {
+		return nil, fmt.Errorf("invalid scenario, %v", err)
+	}
+	return s, nil
+}
+
+// LoadProfiles reads the file of named profiles at the specified path, determining
+// its format from the file's extension as per Load.
+func LoadProfiles(path string) (map[string]*apb.InterfaceStateParams, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read profiles file, %v", err)
+	}
+
+	p, err := ParseProfiles(b, formatOf(path))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse profiles file %s, %v", path, err)
+	}
+	return p, nil
+}
+
+// ParseProfiles parses the named profiles b, which are encoded in the format f,
+// returning them keyed by name.
+func ParseProfiles(b []byte, f Format) (map[string]*apb.InterfaceStateParams, error) {
+	j, err := toJSON(b, f)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(j, &raw); err != nil {
+		return nil, fmt.Errorf("profiles must be a map of name to params, %v", err)
+	}
+
+	profiles := map[string]*apb.InterfaceStateParams{}
+	for name, r := range raw {
+		p := &apb.InterfaceStateParams{}
+		if err := protojson.Unmarshal(r, p); err != nil {
+			return nil, fmt.Errorf("invalid profile %s, %v", name, err)
+		}
+		profiles[name] = p
+	}
+	return profiles, nil
+}
+
+// Validate checks that the scenario s is well-formed, and that each interface that
+// it names is one of the specified interfaces, which are typically those returned
+// by the ListInterfaces RPC of the Aite instance that is to run it. The impairments
+// of each step are checked as they are by Aite, other than those of a step that
+// specifies a profile, which is known only to Aite, and whose params are hence
+// checked when the scenario is run.
+func Validate(s *apb.RunScenarioRequest, interfaces []string) error {
+	if len(s.GetSteps()) == 0 {
+		return fmt.Errorf("at least one step must be specified")
+	}
+
+	available := map[string]bool{}
+	for _, i := range interfaces {
+		available[i] = true
+	}
+	checkName := func(i int, name string) error {
+		if !available[name] {
+			return fmt.Errorf("step %d refers to unknown interface %q", i, name)
+		}
+		return nil
+	}
+
+	for i, step := range s.Steps {
+		if i > 0 && step.OffsetMsec < s.Steps[i-1].OffsetMsec {
+			return fmt.Errorf("step %d is scheduled before step %d", i, i-1)
+		}
+		switch a := step.Action.(type) {
+		case *apb.ScenarioStep_SetInterface:
+			if err := checkName(i, a.SetInterface.GetName()); err != nil {
+				return err
+			}
+			// The state may instead be specified by a profile, which are known
+			// only to Aite.
+			params := a.SetInterface.GetParams()
+			if a.SetInterface.GetProfileName() != "" {
+				params = nil
+			} else if params.GetState() == apb.InterfaceState_IS_UNSPECIFIED {
+				return fmt.Errorf("step %d does not specify the state of interface %s", i, a.SetInterface.GetName())
+			}
+			if err := srv.ValidateImpairments(params, a.SetInterface.GetFlows()); err != nil {
+				return fmt.Errorf("step %d specifies invalid impairments for interface %s, %v", i, a.SetInterface.GetName(), status.Convert(err).Message())
+			}
+		case *apb.ScenarioStep_ClearInterface:
+			if err := checkName(i, a.ClearInterface.GetName()); err != nil {
+				return err
+			}
+		case *apb.ScenarioStep_ClearAll:
+		default:
+			return fmt.Errorf("step %d does not specify an action", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scenario
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// twoSteps is the scenario that is encoded by the valid inputs of TestParse.
+var twoSteps = &apb.RunScenarioRequest{
+	Restore: true,
+	Steps: []*apb.ScenarioStep{{
+		Action: &apb.ScenarioStep_SetInterface{SetInterface: &apb.SetInterfaceRequest{
+			Name: "eth1",
+			Params: &apb.InterfaceStateParams{
+				State:       apb.InterfaceState_IS_UP,
+				LatencyMsec: 50,
+			},
+		}},
+	}, {
+		OffsetMsec: 30000,
+		Action:     &apb.ScenarioStep_ClearAll{ClearAll: &apb.ClearAllInterfaces{}},
+	}},
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      string
+		format  Format
+		want    *apb.RunScenarioRequest
+		wantErr string
+	}{{
+		desc: "YAML",
+		in: `
+restore: true
+steps:
+  - offsetMsec: 0
+    setInterface:
+      name: eth1
+      params:
+        state: IS_UP
+        latencyMsec: 50
+  - offsetMsec: 30000
+    clearAll: {}
+`,
+		format: YAML,
+		want:   twoSteps,
+	}, {
+		desc: "YAML with proto field names",
+		in: `
+restore: true
+steps:
+  - set_interface:
+      name: eth1
+      params:
+        state: IS_UP
+        latency_msec: 50
+  - offset_msec: 30000
+    clear_all: {}
+`,
+		format: YAML,
+		want:   twoSteps,
+	}, {
+		desc: "JSON",
+		in: `{
+  "restore": true,
+  "steps": [
+    {"setInterface": {"name": "eth1", "params": {"state": "IS_UP", "latencyMsec": 50}}},
+    {"offsetMsec": 30000, "clearAll": {}}
+  ]
+}`,
+		format: JSON,
+		want:   twoSteps,
+	}, {
+		desc: "unknown field in YAML",
+		in: `
+steps:
+  - offsetMsec: 0
+    setInterface:
+      name: eth1
+      params:
+        state: IS_UP
+        latencyMs: 50
+`,
+		format:  YAML,
+		wantErr: `unknown field "latencyMs"`,
+	}, {
+		desc:    "unknown field in JSON",
+		in:      `{"restore": true, "step": []}`,
+		format:  JSON,
+		wantErr: `unknown field "step"`,
+	}, {
+		desc:    "unknown enum value",
+		in:      `{"steps": [{"setInterface": {"name": "eth1", "params": {"state": "IS_SIDEWAYS"}}}]}`,
+		format:  JSON,
+		wantErr: "IS_SIDEWAYS",
+	}, {
+		desc:    "invalid YAML",
+		in:      "steps: [",
+		format:  YAML,
+		wantErr: "invalid YAML",
+	}, {
+		desc:    "YAML parsed as JSON",
+		in:      "restore: true",
+		format:  JSON,
+		wantErr: "invalid scenario",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := Parse([]byte(tt.in), tt.format)
+			if err != nil {
+				if tt.wantErr == "" || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse(): did not get expected error, got: %v, want: %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("Parse(): did not get expected error, got: nil, want: %q", tt.wantErr)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Parse(): did not get expected scenario, got: %s, want: %s", prototext.Format(got), prototext.Format(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseProfiles(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      string
+		format  Format
+		want    map[string]*apb.InterfaceStateParams
+		wantErr string
+	}{{
+		desc: "YAML",
+		in: `
+satellite:
+  state: IS_UP
+  latencyMsec: 600
+  lossPct: 1
+lossy:
+  lossPpm: 500
+`,
+		format: YAML,
+		want: map[string]*apb.InterfaceStateParams{
+			"satellite": {State: apb.InterfaceState_IS_UP, LatencyMsec: 600, LossPct: 1},
+			"lossy":     {LossPpm: 500},
+		},
+	}, {
+		desc:   "JSON",
+		in:     `{"satellite": {"state": "IS_UP", "latencyMsec": 600}}`,
+		format: JSON,
+		want: map[string]*apb.InterfaceStateParams{
+			"satellite": {State: apb.InterfaceState_IS_UP, LatencyMsec: 600},
+		},
+	}, {
+		desc:   "empty",
+		in:     `{}`,
+		format: JSON,
+		want:   map[string]*apb.InterfaceStateParams{},
+	}, {
+		desc: "unknown field",
+		in: `
+satellite:
+  latency: 600
+`,
+		format:  YAML,
+		wantErr: `unknown field "latency"`,
+	}, {
+		desc:    "not a map",
+		in:      "- satellite",
+		format:  YAML,
+		wantErr: "profiles must be a map",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseProfiles([]byte(tt.in), tt.format)
+			if err != nil {
+				if tt.wantErr == "" || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseProfiles(): did not get expected error, got: %v, want: %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("ParseProfiles(): did not get expected error, got: nil, want: %q", tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseProfiles(): did not get expected number of profiles, got: %d, want: %d", len(got), len(tt.want))
+			}
+			for name, want := range tt.want {
+				if !proto.Equal(got[name], want) {
+					t.Errorf("ParseProfiles(): did not get expected profile %s, got: %s, want: %s", name, prototext.Format(got[name]), prototext.Format(want))
+				}
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	set := func(offset uint64, name string, params *apb.InterfaceStateParams, profile string) *apb.ScenarioStep {
+		return &apb.ScenarioStep{
+			OffsetMsec: offset,
+			Action: &apb.ScenarioStep_SetInterface{SetInterface: &apb.SetInterfaceRequest{
+				Name:        name,
+				Params:      params,
+				ProfileName: profile,
+			}},
+		}
+	}
+	up := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP}
+	withFlows := func(step *apb.ScenarioStep, flows ...*apb.FlowImpairment) *apb.ScenarioStep {
+		step.GetSetInterface().Flows = flows
+		return step
+	}
+	invalidFlow := &apb.FlowImpairment{
+		Match:  &apb.FlowMatch{IpProtocol: 1, DstPort: 80},
+		Params: &apb.InterfaceStateParams{LatencyMsec: 10},
+	}
+
+	tests := []struct {
+		desc    string
+		in      *apb.RunScenarioRequest
+		wantErr string
+	}{{
+		desc: "valid",
+		in:   twoSteps,
+	}, {
+		desc: "clear interface",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{{
+			Action: &apb.ScenarioStep_ClearInterface{ClearInterface: &apb.ClearInterfaceRequest{Name: "eth2"}},
+		}}},
+	}, {
+		desc: "equal offsets",
+		in:   &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{set(10, "eth1", up, ""), set(10, "eth2", up, "")}},
+	}, {
+		desc: "profile without state",
+		in:   &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{set(0, "eth1", nil, "satellite")}},
+	}, {
+		desc: "profile with params without state",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			set(0, "eth1", &apb.InterfaceStateParams{LatencyMsec: 10}, "satellite"),
+		}},
+	}, {
+		desc: "flow",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			withFlows(set(0, "eth1", up, ""), &apb.FlowImpairment{
+				Match:  &apb.FlowMatch{IpProtocol: 6, DstPort: 80},
+				Params: &apb.InterfaceStateParams{LatencyMsec: 10},
+			}),
+		}},
+	}, {
+		// The params of a step that specifies a profile are merged into the
+		// profile by Aite, and hence cannot be checked.
+		desc: "profile with invalid params",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			set(0, "eth1", &apb.InterfaceStateParams{ReorderPct: 10}, "satellite"),
+		}},
+	}, {
+		desc: "invalid impairment",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			set(0, "eth1", up, ""),
+			set(10, "eth2", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LossPct: 101}, ""),
+		}},
+		wantErr: "step 1 specifies invalid impairments for interface eth2, loss percentage",
+	}, {
+		desc:    "invalid flow",
+		in:      &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{withFlows(set(0, "eth1", up, ""), invalidFlow)}},
+		wantErr: "step 0 specifies invalid impairments for interface eth1, ports can only be matched",
+	}, {
+		desc:    "invalid flow with profile",
+		in:      &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{withFlows(set(0, "eth1", nil, "satellite"), invalidFlow)}},
+		wantErr: "step 0 specifies invalid impairments for interface eth1, ports can only be matched",
+	}, {
+		desc: "too many flows",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			withFlows(set(0, "eth1", up, ""), make([]*apb.FlowImpairment, 16)...),
+		}},
+		wantErr: "at most 15 flows",
+	}, {
+		desc:    "no steps",
+		in:      &apb.RunScenarioRequest{},
+		wantErr: "at least one step",
+	}, {
+		desc:    "out of order",
+		in:      &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{set(0, "eth1", up, ""), set(20, "eth1", up, ""), set(10, "eth2", up, "")}},
+		wantErr: "step 2 is scheduled before step 1",
+	}, {
+		desc:    "unknown interface",
+		in:      &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{set(0, "eth3", up, "")}},
+		wantErr: `step 0 refers to unknown interface "eth3"`,
+	}, {
+		desc: "unknown cleared interface",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{set(0, "eth1", up, ""), {
+			Action: &apb.ScenarioStep_ClearInterface{ClearInterface: &apb.ClearInterfaceRequest{Name: "eth3"}},
+		}}},
+		wantErr: `step 1 refers to unknown interface "eth3"`,
+	}, {
+		desc:    "no state",
+		in:      &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{set(0, "eth1", &apb.InterfaceStateParams{LatencyMsec: 10}, "")}},
+		wantErr: "step 0 does not specify the state of interface eth1",
+	}, {
+		desc:    "no action",
+		in:      &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{{OffsetMsec: 10}}},
+		wantErr: "step 0 does not specify an action",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := Validate(tt.in, []string{"eth1", "eth2"})
+			switch {
+			case err == nil && tt.wantErr == "":
+			case err == nil, tt.wantErr == "", !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("Validate(): did not get expected error, got: %v, want: %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backend defines the interfaces through which Aite reads and programs the
+// state of links and their traffic control configuration, such that the translation
+// of requests into qdiscs and filters is independent of how they are applied. The
+// Netlink backend programs the kernel, and is used by default.
+package backend
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/florianl/go-tc"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+)
+
+// ErrLinkNotFound is returned, wrapped, by a backend when a link does not exist.
+var ErrLinkNotFound = errors.New("link not found")
+
+// ErrNotificationsDropped is returned, wrapped, by a subscription when notifications
+// have been dropped, such that not every change can be reported.
+var ErrNotificationsDropped = errors.New("notifications dropped")
+
+// Links reads and programs the state of the links within a network namespace.
+type Links interface {
+	// LinkList returns the attributes of each link.
+	LinkList() ([]*netlink.LinkAttrs, error)
+	// LinkByName returns the attributes of the link with the specified name,
+	// or an error wrapping ErrLinkNotFound if it does not exist.
+	LinkByName(name string) (*netlink.LinkAttrs, error)
+	// LinkSetState sets the link with the specified name to be administratively
+	// up or down.
+	LinkSetState(name string, up bool) error
+	// LinkSetCarrier sets the carrier of the link with the specified index to be
+	// up or down. Links that do not support their carrier being set return an
+	// error wrapping unix.EOPNOTSUPP.
+	LinkSetCarrier(index int, up bool) error
+	// LinkAddIfb creates an intermediate functional block device with the
+	// specified name, and brings it up. It is not an error for the device to
+	// exist.
+	LinkAddIfb(name string) error
+	// LinkDel deletes the link with the specified name.
+	LinkDel(name string) error
+}
+
+// TrafficControl reads and programs the qdiscs and filters attached to the links
+// within a network namespace. Options are passed in the encoding of the TCA_OPTIONS
+// attribute used by the kernel.
+type TrafficControl interface {
+	// QdiscList returns every qdisc that is attached to a link.
+	QdiscList() ([]*Qdisc, error)
+	// QdiscReplace creates the qdisc described by msg with the specified kind
+	// and options, replacing any existing qdisc with the same parent.
+	QdiscReplace(msg tc.Msg, kind string, options []byte) error
+	// QdiscDel deletes the qdisc described by msg, along with its children and
+	// the filters attached to it.
+	QdiscDel(msg tc.Msg) error
+	// FilterAdd attaches the filter described by msg, whose Info is the priority
+	// and protocol of the filter, with the specified kind and options. It is an
+	// error for a filter with the same priority and protocol to exist.
+	FilterAdd(msg tc.Msg, kind string, options []byte) error
+	// RedirectIngress attaches an ingress qdisc to the link with the specified
+	// index, with a filter that redirects every packet that it receives to the
+	// egress of the link with the index target. Reapplying the redirection
+	// replaces, rather than duplicates, it.
+	RedirectIngress(index, target int) error
+}
+
+// Backend is the combination of the links and traffic control of a network
+// namespace that Aite manipulates.
+type Backend interface {
+	Links
+	TrafficControl
+	// Subscribe returns a subscription to notifications of changes to the links
+	// and qdiscs within the namespace, which are made after it returns.
+	Subscribe() (Subscription, error)
+	// Close releases any resources held by the backend.
+	Close() error
+}
+
+// Subscription delivers notifications of changes to the links and qdiscs within a
+// network namespace.
+type Subscription interface {
+	// Receive blocks until at least one notification is available, returning
+	// the available notifications in the order in which the changes were made,
+	// or until ctx is done, returning its error. It returns an error wrapping
+	// ErrNotificationsDropped if notifications have been dropped.
+	Receive(ctx context.Context) ([]*Notification, error)
+	// Close ends the subscription.
+	Close() error
+}
+
+// Notification describes a change to a link or qdisc.
+type Notification struct {
+	// Type is the type of the rtnetlink message with which the kernel notifies
+	// of the change, one of RTM_NEWLINK, RTM_DELLINK, RTM_NEWQDISC or
+	// RTM_DELQDISC.
+	Type uint16
+	// Link is the attributes of the link, set for link notifications.
+	Link *netlink.LinkAttrs
+	// Qdisc is the qdisc, set for qdisc notifications.
+	Qdisc *Qdisc
+}
+
+// Qdisc is a queueing discipline attached to a link.
+type Qdisc struct {
+	tc.Msg
+	// Kind is the kind of the qdisc, e.g., netem.
+	Kind string
+	// Options is the TCA_OPTIONS attribute of the qdisc as encoded by the kernel.
+	Options []byte
+	// Stats is the statistics of the qdisc, nil if they are not reported.
+	Stats *QdiscStats
+}
+
+// QdiscStats are the statistics that the kernel maintains for a qdisc, as reported
+// within its TCA_STATS2 attribute.
+type QdiscStats struct {
+	// Bytes and Packets are the number of bytes and packets dequeued by the qdisc.
+	Bytes   uint64
+	Packets uint64
+	// Qlen and Backlog are the number of packets and bytes queued by the qdisc.
+	Qlen    uint32
+	Backlog uint32
+	// Drops, Requeues and Overlimits are the number of packets dropped and
+	// requeued by the qdisc, and the number of times it exceeded its limit.
+	Drops      uint32
+	Requeues   uint32
+	Overlimits uint32
+}
+
+// Htons converts the 16-bit value v from host to network byte order, as is
+// required of protocols within the Info of a filter, and of the 16-bit attributes
+// of a flower filter.
+func Htons(v uint16) uint16 {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	return nl.NativeEndian().Uint16(b)
+}
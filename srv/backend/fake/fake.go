@@ -0,0 +1,566 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fake provides an in-memory Aite backend, which records the links, qdiscs
+// and filters that it is asked to program rather than programming the kernel. It
+// allows the validation and translation of requests by the Aite server to be tested
+// hermetically, without privileges, for example:
+//
+//	b := fake.New()
+//	b.AddLink("eth0", false)
+//	s, err := srv.New(srv.WithBackend(b))
+//	...
+//	root := b.Qdiscs("eth0")[tc.HandleRoot]
+//
+// The fake enforces the structural rules of the kernel, such as qdiscs requiring an
+// existing parent, but does not interpret the options of qdiscs or filters. It
+// notifies subscribers of each change that it makes to links and qdiscs, as the
+// kernel does.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/vishvananda/netlink"
+
+	"github.com/openconfig/aite/srv/backend"
+)
+
+// Filter is a filter attached to a qdisc.
+type Filter struct {
+	tc.Msg
+	// Kind is the kind of the filter, e.g., flower.
+	Kind string
+	// Options is the encoded TCA_OPTIONS attribute of the filter.
+	Options []byte
+}
+
+// link is a link within the fake namespace.
+type link struct {
+	attrs netlink.LinkAttrs
+	// carrier indicates that the carrier of the link is up.
+	carrier bool
+	// noCarrier indicates that the link does not support its carrier being set.
+	noCarrier bool
+}
+
+// Backend is an in-memory backend.Backend. It is safe for concurrent use.
+type Backend struct {
+	// mu protects the fields below.
+	mu sync.Mutex
+	// links stores each link, keyed by name.
+	links map[string]*link
+	// nextIndex is the index assigned to the next link that is created.
+	nextIndex int
+	// qdiscs stores the qdiscs attached to each link, keyed by the index of the
+	// link, and then by the handle of their parent.
+	qdiscs map[int]map[uint32]*backend.Qdisc
+	// filters stores the filters attached to each link, keyed by the index of
+	// the link, in the order in which they were added.
+	filters map[int][]*Filter
+	// redirects stores the index of the link to which the ingress traffic of
+	// each link is redirected, keyed by the index of the link.
+	redirects map[int]int
+	// subs stores the active subscriptions.
+	subs map[*subscription]bool
+}
+
+// maxPendingNotifications is the number of notifications that a subscription holds
+// before further notifications are dropped, as the kernel does when the buffer of a
+// subscribed socket is full.
+const maxPendingNotifications = 1024
+
+var _ backend.Backend = &Backend{}
+
+// New returns a fake backend with no links. Links are added using AddLink.
+func New() *Backend {
+	return &Backend{
+		links:     map[string]*link{},
+		nextIndex: 1,
+		qdiscs:    map[int]map[uint32]*backend.Qdisc{},
+		filters:   map[int][]*Filter{},
+		redirects: map[int]int{},
+		subs:      map[*subscription]bool{},
+	}
+}
+
+// AddLink adds a link with the specified name, which is administratively and
+// operationally up, returning its index. carrier indicates whether the link
+// supports its carrier being set, which virtual links such as veths do not.
+func (b *Backend) AddLink(name string, carrier bool) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.addLink(name, carrier)
+	b.notifyLink(unix.RTM_NEWLINK, l)
+	return l.attrs.Index
+}
+
+// addLink adds a link with the specified name. It must be called with mu held.
+func (b *Backend) addLink(name string, carrier bool) *link {
+	index := b.nextIndex
+	b.nextIndex++
+	l := &link{
+		attrs: netlink.LinkAttrs{
+			Index:        index,
+			Name:         name,
+			MTU:          1500,
+			HardwareAddr: net.HardwareAddr{0x02, 0, 0, 0, byte(index >> 8), byte(index)},
+			Flags:        net.FlagUp | net.FlagBroadcast | net.FlagMulticast,
+			OperState:    netlink.OperUp,
+		},
+		carrier:   true,
+		noCarrier: !carrier,
+	}
+	l.updateOperState()
+	b.links[name] = l
+	return l
+}
+
+// Qdiscs returns a copy of the qdiscs attached to the link with the specified name,
+// keyed by the handle of their parent, such that the root qdisc is keyed by
+// tc.HandleRoot. It returns nil if the link does not exist.
+func (b *Backend) Qdiscs(name string) map[uint32]*backend.Qdisc {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.links[name]
+	if l == nil {
+		return nil
+	}
+	qdiscs := map[uint32]*backend.Qdisc{}
+	for parent, q := range b.qdiscs[l.attrs.Index] {
+		qdiscs[parent] = copyQdisc(q)
+	}
+	return qdiscs
+}
+
+// Filters returns a copy of the filters attached to the link with the specified
+// name, in the order in which they were added.
+func (b *Backend) Filters(name string) []*Filter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.links[name]
+	if l == nil {
+		return nil
+	}
+	filters := []*Filter{}
+	for _, f := range b.filters[l.attrs.Index] {
+		filters = append(filters, &Filter{Msg: f.Msg, Kind: f.Kind, Options: append([]byte{}, f.Options...)})
+	}
+	return filters
+}
+
+// Redirect returns the name of the link to which the ingress traffic of the link
+// with the specified name is redirected, and false if it is not redirected.
+func (b *Backend) Redirect(name string) (string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.links[name]
+	if l == nil {
+		return "", false
+	}
+	target, ok := b.redirects[l.attrs.Index]
+	if !ok {
+		return "", false
+	}
+	return b.linkByIndex(target).attrs.Name, true
+}
+
+// Close is a no-op, since the fake holds no resources.
+func (b *Backend) Close() error {
+	return nil
+}
+
+// LinkList returns a copy of the attributes of each link, ordered by index.
+func (b *Backend) LinkList() ([]*netlink.LinkAttrs, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	attrs := []*netlink.LinkAttrs{}
+	for _, l := range b.links {
+		a := l.attrs
+		attrs = append(attrs, &a)
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Index < attrs[j].Index })
+	return attrs, nil
+}
+
+// LinkByName returns a copy of the attributes of the link with the specified name.
+func (b *Backend) LinkByName(name string) (*netlink.LinkAttrs, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.links[name]
+	if l == nil {
+		return nil, fmt.Errorf("cannot find link %s, %w", name, backend.ErrLinkNotFound)
+	}
+	a := l.attrs
+	return &a, nil
+}
+
+// LinkSetState sets the link with the specified name to be administratively up or
+// down, updating its operational state accordingly.
+func (b *Backend) LinkSetState(name string, up bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.links[name]
+	if l == nil {
+		return fmt.Errorf("cannot find link %s, %w", name, backend.ErrLinkNotFound)
+	}
+	if up {
+		l.attrs.Flags |= net.FlagUp
+	} else {
+		l.attrs.Flags &^= net.FlagUp
+	}
+	l.updateOperState()
+	b.notifyLink(unix.RTM_NEWLINK, l)
+	return nil
+}
+
+// LinkSetCarrier sets the carrier of the link with the specified index to be up or
+// down, returning unix.EOPNOTSUPP if the link does not support its carrier being set.
+func (b *Backend) LinkSetCarrier(index int, up bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.linkByIndex(index)
+	switch {
+	case l == nil:
+		return unix.ENODEV
+	case l.noCarrier:
+		return unix.EOPNOTSUPP
+	}
+	l.carrier = up
+	l.updateOperState()
+	b.notifyLink(unix.RTM_NEWLINK, l)
+	return nil
+}
+
+// updateOperState sets the operational state of l, and the flags that the kernel
+// reports for it, from its administrative state and carrier.
+func (l *link) updateOperState() {
+	l.attrs.RawFlags &^= unix.IFF_UP | unix.IFF_LOWER_UP | unix.IFF_RUNNING
+	switch {
+	case l.attrs.Flags&net.FlagUp == 0:
+		l.attrs.OperState = netlink.OperDown
+	case !l.carrier:
+		l.attrs.RawFlags |= unix.IFF_UP
+		l.attrs.OperState = netlink.OperLowerLayerDown
+	default:
+		l.attrs.RawFlags |= unix.IFF_UP | unix.IFF_LOWER_UP | unix.IFF_RUNNING
+		l.attrs.OperState = netlink.OperUp
+	}
+}
+
+// LinkAddIfb creates an ifb device with the specified name if it does not exist,
+// and brings it up.
+func (b *Backend) LinkAddIfb(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.links[name]
+	if l == nil {
+		l = b.addLink(name, false)
+	}
+	l.attrs.Flags |= net.FlagUp
+	l.updateOperState()
+	b.notifyLink(unix.RTM_NEWLINK, l)
+	return nil
+}
+
+// LinkDel deletes the link with the specified name, along with its qdiscs and
+// filters, and any redirection of traffic to or from it.
+func (b *Backend) LinkDel(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l := b.links[name]
+	if l == nil {
+		return fmt.Errorf("cannot find link %s, %w", name, backend.ErrLinkNotFound)
+	}
+	index := l.attrs.Index
+	delete(b.links, name)
+	delete(b.qdiscs, index)
+	delete(b.filters, index)
+	for from, to := range b.redirects {
+		if from == index || to == index {
+			delete(b.redirects, from)
+		}
+	}
+	b.notifyLink(unix.RTM_DELLINK, l)
+	return nil
+}
+
+// linkByIndex returns the link with the specified index, or nil if it does not
+// exist. It must be called with mu held.
+func (b *Backend) linkByIndex(index int) *link {
+	for _, l := range b.links {
+		if l.attrs.Index == index {
+			return l
+		}
+	}
+	return nil
+}
+
+// QdiscList returns a copy of every qdisc, ordered by the index of their link and
+// then by the handle of their parent.
+func (b *Backend) QdiscList() ([]*backend.Qdisc, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	qdiscs := []*backend.Qdisc{}
+	for _, tree := range b.qdiscs {
+		for _, q := range tree {
+			qdiscs = append(qdiscs, copyQdisc(q))
+		}
+	}
+	sort.Slice(qdiscs, func(i, j int) bool {
+		if qdiscs[i].Ifindex != qdiscs[j].Ifindex {
+			return qdiscs[i].Ifindex < qdiscs[j].Ifindex
+		}
+		return qdiscs[i].Parent < qdiscs[j].Parent
+	})
+	return qdiscs, nil
+}
+
+// QdiscReplace records the qdisc described by msg with the specified kind and
+// options, replacing any existing qdisc with the same parent. Where the existing
+// qdisc is of a different kind or has a different handle, its children and filters
+// are removed, as they are by the kernel when a qdisc is grafted in its place.
+func (b *Backend) QdiscReplace(msg tc.Msg, kind string, options []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	index := int(msg.Ifindex)
+	if b.linkByIndex(index) == nil {
+		return fmt.Errorf("cannot replace %s qdisc, %w", kind, unix.ENODEV)
+	}
+	if msg.Parent != tc.HandleRoot && msg.Parent != tc.HandleIngress && b.qdiscByHandle(index, parentHandle(msg.Parent)) == nil {
+		return fmt.Errorf("cannot replace %s qdisc, %w", kind, unix.ENOENT)
+	}
+
+	tree := b.qdiscs[index]
+	if tree == nil {
+		tree = map[uint32]*backend.Qdisc{}
+		b.qdiscs[index] = tree
+	}
+	if old := tree[msg.Parent]; old != nil && (old.Handle != msg.Handle || old.Kind != kind) {
+		b.removeQdisc(index, old)
+	}
+	tree[msg.Parent] = &backend.Qdisc{
+		Msg:     msg,
+		Kind:    kind,
+		Options: append([]byte{}, options...),
+	}
+	b.notifyQdisc(unix.RTM_NEWQDISC, tree[msg.Parent])
+	return nil
+}
+
+// QdiscDel deletes the qdisc described by msg, along with its children and the
+// filters attached to it.
+func (b *Backend) QdiscDel(msg tc.Msg) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q := b.qdiscs[int(msg.Ifindex)][msg.Parent]
+	if q == nil || (msg.Handle != 0 && q.Handle != msg.Handle) {
+		return fmt.Errorf("cannot delete qdisc, %w", unix.ENOENT)
+	}
+	b.removeQdisc(int(msg.Ifindex), q)
+	return nil
+}
+
+// removeQdisc removes q from the link with the specified index, along with its
+// children and the filters attached to it. It must be called with mu held.
+func (b *Backend) removeQdisc(index int, q *backend.Qdisc) {
+	tree := b.qdiscs[index]
+	delete(tree, q.Parent)
+	b.notifyQdisc(unix.RTM_DELQDISC, q)
+	for _, c := range tree {
+		if c.Parent != tc.HandleRoot && c.Parent != tc.HandleIngress && parentHandle(c.Parent) == q.Handle {
+			b.removeQdisc(index, c)
+		}
+	}
+
+	filters := []*Filter{}
+	for _, f := range b.filters[index] {
+		if f.Parent != q.Handle {
+			filters = append(filters, f)
+		}
+	}
+	b.filters[index] = filters
+
+	if q.Parent == tc.HandleIngress {
+		delete(b.redirects, index)
+	}
+}
+
+// qdiscByHandle returns the qdisc with the specified handle on the link with the
+// specified index, or nil if there is none. It must be called with mu held.
+func (b *Backend) qdiscByHandle(index int, handle uint32) *backend.Qdisc {
+	for _, q := range b.qdiscs[index] {
+		if q.Handle == handle {
+			return q
+		}
+	}
+	return nil
+}
+
+// FilterAdd records the filter described by msg with the specified kind and options.
+// The qdisc to which it is attached must exist, and no filter with the same priority
+// and protocol may be attached to it.
+func (b *Backend) FilterAdd(msg tc.Msg, kind string, options []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	index := int(msg.Ifindex)
+	if b.qdiscByHandle(index, msg.Parent) == nil {
+		return fmt.Errorf("cannot add %s filter, %w", kind, unix.ENOENT)
+	}
+	for _, f := range b.filters[index] {
+		if f.Parent == msg.Parent && f.Info == msg.Info {
+			return fmt.Errorf("cannot add %s filter, %w", kind, unix.EEXIST)
+		}
+	}
+	b.filters[index] = append(b.filters[index], &Filter{
+		Msg:     msg,
+		Kind:    kind,
+		Options: append([]byte{}, options...),
+	})
+	return nil
+}
+
+// RedirectIngress attaches an ingress qdisc to the link with the specified index,
+// and records that its ingress traffic is redirected to the link with the index
+// target.
+func (b *Backend) RedirectIngress(index, target int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.linkByIndex(index) == nil || b.linkByIndex(target) == nil {
+		return fmt.Errorf("cannot redirect ingress traffic, %w", unix.ENODEV)
+	}
+
+	tree := b.qdiscs[index]
+	if tree == nil {
+		tree = map[uint32]*backend.Qdisc{}
+		b.qdiscs[index] = tree
+	}
+	if tree[tc.HandleIngress] == nil {
+		tree[tc.HandleIngress] = &backend.Qdisc{
+			Msg: tc.Msg{
+				Family:  unix.AF_UNSPEC,
+				Ifindex: uint32(index),
+				Handle:  core.BuildHandle(0xFFFF, 0x0),
+				Parent:  tc.HandleIngress,
+			},
+			Kind: "ingress",
+		}
+		b.notifyQdisc(unix.RTM_NEWQDISC, tree[tc.HandleIngress])
+	}
+	b.redirects[index] = target
+	return nil
+}
+
+// subscription is a subscription to the notifications of a fake backend.
+type subscription struct {
+	b *Backend
+	// ready is signalled when notifications become pending.
+	ready chan struct{}
+	// pending stores the notifications that have not been received, and dropped
+	// indicates that notifications were dropped since the last receive. They
+	// are protected by the mu of b.
+	pending []*backend.Notification
+	dropped bool
+}
+
+// Subscribe returns a subscription to notifications of the changes that the backend
+// subsequently makes to links and qdiscs.
+func (b *Backend) Subscribe() (backend.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := &subscription{b: b, ready: make(chan struct{}, 1)}
+	b.subs[s] = true
+	return s, nil
+}
+
+// Receive returns the pending notifications, blocking until there are any.
+func (s *subscription) Receive(ctx context.Context) ([]*backend.Notification, error) {
+	for {
+		s.b.mu.Lock()
+		pending, dropped := s.pending, s.dropped
+		s.pending, s.dropped = nil, false
+		s.b.mu.Unlock()
+
+		switch {
+		case dropped:
+			return nil, fmt.Errorf("cannot receive notifications, %w", backend.ErrNotificationsDropped)
+		case len(pending) != 0:
+			return pending, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.ready:
+		}
+	}
+}
+
+// Close ends the subscription.
+func (s *subscription) Close() error {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	delete(s.b.subs, s)
+	return nil
+}
+
+// notifyLink notifies subscribers of a change of type t to l. It must be called
+// with mu held.
+func (b *Backend) notifyLink(t uint16, l *link) {
+	a := l.attrs
+	b.notify(&backend.Notification{Type: t, Link: &a})
+}
+
+// notifyQdisc notifies subscribers of a change of type t to q. It must be called
+// with mu held.
+func (b *Backend) notifyQdisc(t uint16, q *backend.Qdisc) {
+	b.notify(&backend.Notification{Type: t, Qdisc: copyQdisc(q)})
+}
+
+// notify delivers n to each subscription, dropping it for those that hold
+// maxPendingNotifications. It must be called with mu held.
+func (b *Backend) notify(n *backend.Notification) {
+	for s := range b.subs {
+		if len(s.pending) >= maxPendingNotifications {
+			s.dropped = true
+		} else {
+			s.pending = append(s.pending, n)
+		}
+		select {
+		case s.ready <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// parentHandle returns the handle of the qdisc that owns the class parent.
+func parentHandle(parent uint32) uint32 {
+	major, _ := core.SplitHandle(parent)
+	return core.BuildHandle(major, 0x0)
+}
+
+// copyQdisc returns a copy of q.
+func copyQdisc(q *backend.Qdisc) *backend.Qdisc {
+	c := *q
+	c.Options = append([]byte{}, q.Options...)
+	return &c
+}
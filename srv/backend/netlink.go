@@ -0,0 +1,454 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"k8s.io/klog"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/openconfig/magna/intf"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+)
+
+// Netlink is a Backend that programs the kernel of the network namespace within
+// which it runs via rtnetlink.
+//
+// The tc package cannot encode or decode netem qdiscs that use a loss model, and
+// fails to return any qdiscs from a dump when such a qdisc is present. Thus, qdiscs
+// and filters are dumped and programmed directly, with their options passed through
+// in the kernel's encoding.
+type Netlink struct {
+	tc *tc.Tc
+}
+
+var _ Backend = &Netlink{}
+
+// NewNetlink returns a Netlink backend.
+func NewNetlink() (*Netlink, error) {
+	tconn, err := tc.Open(&tc.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot open Tc connection, %w", err)
+	}
+	return &Netlink{tc: tconn}, nil
+}
+
+// Close closes the backend's connection to the kernel.
+func (n *Netlink) Close() error {
+	if err := n.tc.Close(); err != nil {
+		return fmt.Errorf("cannot close Tc connection, %v", err)
+	}
+	return nil
+}
+
+// LinkList returns the attributes of each link in the namespace.
+func (n *Netlink) LinkList() ([]*netlink.LinkAttrs, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	attrs := []*netlink.LinkAttrs{}
+	for _, l := range links {
+		attrs = append(attrs, l.Attrs())
+	}
+	return attrs, nil
+}
+
+// LinkByName returns the attributes of the link with the specified name.
+func (n *Netlink) LinkByName(name string) (*netlink.LinkAttrs, error) {
+	link, err := netlink.LinkByName(name)
+	switch {
+	case errors.As(err, &netlink.LinkNotFoundError{}):
+		return nil, fmt.Errorf("cannot find link %s, %w", name, ErrLinkNotFound)
+	case err != nil:
+		return nil, fmt.Errorf("cannot find link %s, %v", name, err)
+	}
+	return link.Attrs(), nil
+}
+
+// LinkSetState sets the link with the specified name to be administratively up or
+// down.
+func (n *Netlink) LinkSetState(name string, up bool) error {
+	st := intf.InterfaceDown
+	if up {
+		st = intf.InterfaceUp
+	}
+	return intf.InterfaceState(name, st)
+}
+
+// LinkSetCarrier sets the carrier of the link with the specified index to be up or
+// down. Many virtual links, including veths, do not support their carrier being set,
+// in which case unix.EOPNOTSUPP is returned.
+func (n *Netlink) LinkSetCarrier(index int, up bool) error {
+	req := nl.NewNetlinkRequest(unix.RTM_NEWLINK, unix.NLM_F_ACK)
+	msg := nl.NewIfInfomsg(unix.AF_UNSPEC)
+	msg.Index = int32(index)
+	req.AddData(msg)
+
+	var carrier uint8
+	if up {
+		carrier = 1
+	}
+	req.AddData(nl.NewRtAttr(unix.IFLA_CARRIER, nl.Uint8Attr(carrier)))
+
+	_, err := req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
+
+// LinkAddIfb creates an ifb device with the specified name if it does not exist,
+// and brings it up.
+func (n *Netlink) LinkAddIfb(name string) error {
+	link, err := netlink.LinkByName(name)
+	switch {
+	case errors.As(err, &netlink.LinkNotFoundError{}):
+		link = &netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: name}}
+		if err := netlink.LinkAdd(link); err != nil {
+			return fmt.Errorf("cannot create ifb device %s, %v", name, err)
+		}
+	case err != nil:
+		return fmt.Errorf("cannot find ifb device %s, %v", name, err)
+	}
+
+	if err := netlink.LinkSetUp(link); err != nil {
+		return fmt.Errorf("cannot bring up ifb device %s, %v", name, err)
+	}
+	return nil
+}
+
+// LinkDel deletes the link with the specified name.
+func (n *Netlink) LinkDel(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("cannot find link %s, %v", name, err)
+	}
+	return netlink.LinkDel(link)
+}
+
+// tcaStatsPkt64 is the TCA_STATS_PKT64 attribute, which newer kernels include
+// alongside TCA_STATS_BASIC to report the number of packets as a 64-bit value.
+const tcaStatsPkt64 = 8
+
+// QdiscList returns all qdiscs that are attached to links in the namespace.
+func (n *Netlink) QdiscList() ([]*Qdisc, error) {
+	req := nl.NewNetlinkRequest(unix.RTM_GETQDISC, unix.NLM_F_DUMP)
+	req.AddData(&nl.TcMsg{Family: nl.FAMILY_ALL})
+
+	msgs, err := req.Execute(unix.NETLINK_ROUTE, unix.RTM_NEWQDISC)
+	if err != nil {
+		return nil, fmt.Errorf("cannot dump qdiscs, %v", err)
+	}
+
+	qdiscs := []*Qdisc{}
+	for _, m := range msgs {
+		q, err := parseQdisc(m)
+		if err != nil {
+			return nil, err
+		}
+		qdiscs = append(qdiscs, q)
+	}
+	return qdiscs, nil
+}
+
+// parseQdisc parses the qdisc in the payload m of an RTM_NEWQDISC or RTM_DELQDISC
+// message, such as those that the kernel sends to subscribers of RTNLGRP_TC.
+func parseQdisc(m []byte) (*Qdisc, error) {
+	if len(m) < nl.SizeofTcMsg {
+		return nil, fmt.Errorf("invalid qdisc message, length %d", len(m))
+	}
+	msg := nl.DeserializeTcMsg(m)
+	q := &Qdisc{
+		Msg: tc.Msg{
+			Family:  uint32(msg.Family),
+			Ifindex: uint32(msg.Ifindex),
+			Handle:  msg.Handle,
+			Parent:  msg.Parent,
+			Info:    msg.Info,
+		},
+	}
+
+	attrs, err := nl.ParseRouteAttr(m[msg.Len():])
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse qdisc attributes, %v", err)
+	}
+	for _, a := range attrs {
+		switch a.Attr.Type {
+		case nl.TCA_KIND:
+			q.Kind = nl.BytesToString(a.Value)
+		case nl.TCA_OPTIONS:
+			q.Options = append([]byte{}, a.Value...)
+		case nl.TCA_STATS2:
+			st, err := parseStats(a.Value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse qdisc statistics on interface index %d, %v", q.Ifindex, err)
+			}
+			q.Stats = st
+		}
+	}
+	return q, nil
+}
+
+// parseStats parses the nested attributes of the TCA_STATS2 attribute b.
+func parseStats(b []byte) (*QdiscStats, error) {
+	attrs, err := nl.ParseRouteAttr(b)
+	if err != nil {
+		return nil, err
+	}
+
+	native := nl.NativeEndian()
+	st := &QdiscStats{}
+	for _, a := range attrs {
+		v := a.Value
+		switch a.Attr.Type {
+		case nl.TCA_STATS_BASIC:
+			// struct gnet_stats_basic is a 64-bit byte count followed by a
+			// 32-bit packet count.
+			if len(v) < 12 {
+				return nil, fmt.Errorf("invalid basic statistics, length %d", len(v))
+			}
+			st.Bytes = native.Uint64(v[0:8])
+			st.Packets = uint64(native.Uint32(v[8:12]))
+		case tcaStatsPkt64:
+			// The kernel emits TCA_STATS_PKT64 after TCA_STATS_BASIC, and hence
+			// it supersedes the 32-bit packet count.
+			if len(v) < 8 {
+				return nil, fmt.Errorf("invalid packet count, length %d", len(v))
+			}
+			st.Packets = native.Uint64(v[0:8])
+		case nl.TCA_STATS_QUEUE:
+			// struct gnet_stats_queue is five 32-bit counters.
+			if len(v) < 20 {
+				return nil, fmt.Errorf("invalid queue statistics, length %d", len(v))
+			}
+			st.Qlen = native.Uint32(v[0:4])
+			st.Backlog = native.Uint32(v[4:8])
+			st.Drops = native.Uint32(v[8:12])
+			st.Requeues = native.Uint32(v[12:16])
+			st.Overlimits = native.Uint32(v[16:20])
+		}
+	}
+	return st, nil
+}
+
+// QdiscReplace creates the qdisc described by msg with the specified kind and
+// encoded options, replacing any existing qdisc with the same parent.
+func (n *Netlink) QdiscReplace(msg tc.Msg, kind string, options []byte) error {
+	req := nl.NewNetlinkRequest(unix.RTM_NEWQDISC, unix.NLM_F_CREATE|unix.NLM_F_REPLACE|unix.NLM_F_ACK)
+	req.AddData(tcMsg(msg))
+	req.AddData(nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated(kind)))
+	if len(options) != 0 {
+		req.AddData(nl.NewRtAttr(nl.TCA_OPTIONS, options))
+	}
+
+	if _, err := req.Execute(unix.NETLINK_ROUTE, 0); err != nil {
+		return fmt.Errorf("cannot replace %s qdisc, %v", kind, err)
+	}
+	return nil
+}
+
+// QdiscDel deletes the qdisc described by msg.
+func (n *Netlink) QdiscDel(msg tc.Msg) error {
+	req := nl.NewNetlinkRequest(unix.RTM_DELQDISC, unix.NLM_F_ACK)
+	req.AddData(tcMsg(msg))
+
+	if _, err := req.Execute(unix.NETLINK_ROUTE, 0); err != nil {
+		return fmt.Errorf("cannot delete qdisc, %v", err)
+	}
+	return nil
+}
+
+// FilterAdd attaches the filter described by msg with the specified kind and
+// options, which are the nested attributes of its TCA_OPTIONS attribute.
+func (n *Netlink) FilterAdd(msg tc.Msg, kind string, options []byte) error {
+	req := nl.NewNetlinkRequest(unix.RTM_NEWTFILTER, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	m := tcMsg(msg)
+	m.Info = msg.Info
+	req.AddData(m)
+	req.AddData(nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated(kind)))
+	req.AddData(nl.NewRtAttr(nl.TCA_OPTIONS|unix.NLA_F_NESTED, options))
+
+	if _, err := req.Execute(unix.NETLINK_ROUTE, 0); err != nil {
+		return fmt.Errorf("cannot add %s filter, %v", kind, err)
+	}
+	return nil
+}
+
+// u32Handle is the handle of the u32 filter that redirects ingress traffic, 800::800
+// in tc's notation, composed of a 12-bit hash table ID, 8-bit bucket and 12-bit node.
+const u32Handle = 0x800<<20 | 0x800
+
+// RedirectIngress attaches an ingress qdisc to the link with the specified index,
+// with a filter that redirects all packets that it receives to the egress of the
+// link with the index target.
+func (n *Netlink) RedirectIngress(index, target int) error {
+	if err := n.tc.Qdisc().Replace(&tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: uint32(index),
+			Handle:  core.BuildHandle(0xFFFF, 0x0),
+			Parent:  tc.HandleIngress,
+		},
+		Attribute: tc.Attribute{Kind: "ingress"},
+	}); err != nil {
+		return fmt.Errorf("cannot attach ingress qdisc, %v", err)
+	}
+
+	// A single u32 filter that matches all packets, of any protocol, redirects
+	// them to the egress of the target. The filter is installed as node 0x800 of
+	// the default hash table 0x800, such that it is replaced rather than
+	// duplicated when the redirection is reapplied.
+	if err := n.tc.Filter().Replace(&tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: uint32(index),
+			Handle:  u32Handle,
+			Parent:  core.BuildHandle(0xFFFF, 0x0),
+			Info:    core.BuildHandle(0x1, uint32(Htons(unix.ETH_P_ALL))),
+		},
+		Attribute: tc.Attribute{
+			Kind: "u32",
+			U32: &tc.U32{
+				Sel: &tc.U32Sel{
+					Flags: nl.TC_U32_TERMINAL,
+					NKeys: 1,
+					Keys:  []tc.U32Key{{}},
+				},
+				Actions: &[]*tc.Action{{
+					Kind: "mirred",
+					Mirred: &tc.Mirred{
+						Parms: &tc.MirredParam{
+							Action:  uint32(netlink.TC_ACT_STOLEN),
+							Eaction: uint32(netlink.TCA_EGRESS_REDIR),
+							IfIndex: uint32(target),
+						},
+					},
+				}},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("cannot redirect ingress traffic to interface index %d, %v", target, err)
+	}
+	return nil
+}
+
+// tcMsg returns the netlink representation of msg.
+func tcMsg(msg tc.Msg) *nl.TcMsg {
+	return &nl.TcMsg{
+		Family:  uint8(msg.Family),
+		Ifindex: int32(msg.Ifindex),
+		Handle:  msg.Handle,
+		Parent:  msg.Parent,
+	}
+}
+
+// subscriptionPollInterval is the maximum time for which a subscription waits for a
+// notification from the kernel before checking whether the receive is cancelled.
+const subscriptionPollInterval = 500 * time.Millisecond
+
+// netlinkSubscription is a subscription to the link and tc notifications of the
+// kernel.
+type netlinkSubscription struct {
+	sock *nl.NetlinkSocket
+}
+
+// Subscribe subscribes to the link and tc notifications of the kernel.
+func (n *Netlink) Subscribe() (Subscription, error) {
+	sock, err := nl.Subscribe(unix.NETLINK_ROUTE, unix.RTNLGRP_LINK, unix.RTNLGRP_TC)
+	if err != nil {
+		return nil, fmt.Errorf("cannot subscribe to netlink notifications, %v", err)
+	}
+	tv := unix.NsecToTimeval(subscriptionPollInterval.Nanoseconds())
+	if err := sock.SetReceiveTimeout(&tv); err != nil {
+		sock.Close()
+		return nil, fmt.Errorf("cannot set netlink receive timeout, %v", err)
+	}
+	return &netlinkSubscription{sock: sock}, nil
+}
+
+// Receive receives notifications from the kernel. Notifications that cannot be
+// parsed are logged and discarded.
+func (s *netlinkSubscription) Receive(ctx context.Context) ([]*Notification, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		msgs, from, err := s.sock.Receive()
+		switch {
+		case errors.Is(err, unix.EAGAIN), errors.Is(err, unix.EINTR):
+			continue
+		case errors.Is(err, unix.ENOBUFS):
+			// The kernel drops notifications when the socket's buffer is full.
+			return nil, fmt.Errorf("%w by the kernel, %v", ErrNotificationsDropped, err)
+		case err != nil:
+			return nil, fmt.Errorf("cannot receive netlink notifications, %v", err)
+		}
+		if from.Pid != nl.PidKernel {
+			continue
+		}
+
+		var notifications []*Notification
+		for _, m := range msgs {
+			n, err := parseNotification(m)
+			if err != nil {
+				klog.Errorf("cannot parse netlink notification, %v", err)
+				continue
+			}
+			if n != nil {
+				notifications = append(notifications, n)
+			}
+		}
+		if len(notifications) != 0 {
+			return notifications, nil
+		}
+	}
+}
+
+// Close closes the subscription's socket.
+func (s *netlinkSubscription) Close() error {
+	s.sock.Close()
+	return nil
+}
+
+// parseNotification parses the netlink message m, returning nil if it is not a
+// link or qdisc notification.
+func parseNotification(m syscall.NetlinkMessage) (*Notification, error) {
+	n := &Notification{Type: m.Header.Type}
+	switch m.Header.Type {
+	case unix.RTM_NEWLINK, unix.RTM_DELLINK:
+		hdr := unix.NlMsghdr(m.Header)
+		link, err := netlink.LinkDeserialize(&hdr, m.Data)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse link, %v", err)
+		}
+		n.Link = link.Attrs()
+	case unix.RTM_NEWQDISC, unix.RTM_DELQDISC:
+		q, err := parseQdisc(m.Data)
+		if err != nil {
+			return nil, err
+		}
+		n.Qdisc = q
+	default:
+		return nil, nil
+	}
+	return n, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog"
+
+	"github.com/openconfig/magna/intf"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv/backend"
+)
+
+// BatchSetInterfaces implements the BatchSetInterfaces RPC for the Aite service.
+// Every request in the batch is validated before any interface is changed. If an
+// interface cannot be changed, each interface already changed by the batch is
+// restored to the state that it was in before the batch was applied, and returned
+// to the lease under which it was previously changed, if any. Any pending revert of
+// an interface that the batch changes is cancelled, even if the batch is rolled
+// back, whereas those of the interfaces that it did not reach are retained.
+func (s *S) BatchSetInterfaces(ctx context.Context, req *apb.BatchSetInterfacesRequest) (*apb.BatchSetInterfacesResponse, error) {
+	if len(req.Requests) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one request must be specified")
+	}
+
+	reqs := make([]*apb.SetInterfaceRequest, len(req.Requests))
+	states := make([]intf.IntState, len(req.Requests))
+	seen := map[string]bool{}
+	for i, r := range req.Requests {
+		r, iState, err := s.validateSetRequest(r)
+		if err != nil {
+			return nil, status.Errorf(status.Code(err), "invalid request %d, %s", i, status.Convert(err).Message())
+		}
+		if seen[r.Name] {
+			return nil, status.Errorf(codes.InvalidArgument, "interface %s specified more than once", r.Name)
+		}
+		seen[r.Name] = true
+		reqs[i], states[i] = r, iState
+	}
+
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+
+	// Leases and flaps are checked, and each interface recorded, before any change is made
+	// such that the batch is not partially applied where this can be avoided.
+	snaps := make([]*snapshot, len(reqs))
+	owners := make([]*ownership, len(reqs))
+	for i, r := range reqs {
+		if err := s.checkNotFlapping(r.Name); err != nil {
+			return nil, err
+		}
+		if _, err := s.leaseFor(r.LeaseId, r.Name); err != nil {
+			return nil, err
+		}
+		snap, err := s.snapshot(r.Name)
+		switch {
+		case errors.Is(err, backend.ErrLinkNotFound):
+			return nil, status.Errorf(codes.NotFound, "cannot record state of interface %s, %v", r.Name, err)
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "cannot record state of interface %s, %v", r.Name, err)
+		}
+		snaps[i] = snap
+		owners[i] = s.ownership(r.Name)
+	}
+
+	resp := &apb.BatchSetInterfacesResponse{}
+	for i, r := range reqs {
+		sr, err := s.setInterface(ctx, r, states[i])
+		if err != nil {
+			klog.Errorf("cannot set interface %s, rolling back batch, %v", r.Name, err)
+			rerr := s.rollback(snaps[:i+1])
+			for _, o := range owners[:i+1] {
+				s.restoreOwnership(o)
+			}
+			if rerr != nil {
+				return nil, status.Errorf(codes.Internal, "cannot set interface %s, %v, and cannot roll back batch, %v", r.Name, err, rerr)
+			}
+			return nil, status.Errorf(codes.Aborted, "cannot set interface %s, batch rolled back, %v", r.Name, err)
+		}
+		resp.Responses = append(resp.Responses, sr)
+	}
+	return resp, nil
+}
+
+// rollback restores each interface described by snaps, in the reverse order to
+// that in which they were changed. It must be called with changeMu held.
+func (s *S) rollback(snaps []*snapshot) error {
+	var errs []error
+	for i := len(snaps) - 1; i >= 0; i-- {
+		snap := snaps[i]
+		s.cancelRevert(snap.name)
+		klog.Infof("rolling back device %s", snap.name)
+		if err := s.restore(snap); err != nil {
+			errs = append(errs, fmt.Errorf("cannot roll back device %s, %v", snap.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ownership is the lease under which an interface was last changed, along with the
+// state of the interface that the lease recorded before its first change to it.
+type ownership struct {
+	// name is the name of the interface.
+	name string
+	// lease is the lease that owns the interface, nil if it is not owned.
+	lease *lease
+	// snap is the state of the interface recorded by lease.
+	snap *snapshot
+}
+
+// ownership returns the current ownership of the interface with the specified name.
+// It must be called with changeMu held.
+func (s *S) ownership(name string) *ownership {
+	o := &ownership{name: name, lease: s.owners[name]}
+	if o.lease != nil {
+		o.snap = o.lease.snaps[name]
+	}
+	return o
+}
+
+// restoreOwnership returns the interface described by o to the lease that owned
+// it, such that the lease restores it when it ends, and releases it from any other
+// lease. The interface is not returned to a lease that is no longer active. It must
+// be called with changeMu held.
+func (s *S) restoreOwnership(o *ownership) {
+	s.releaseInterface(o.name)
+	if o.lease == nil || s.leases[o.lease.id] != o.lease {
+		return
+	}
+	o.lease.snaps[o.name] = o.snap
+	s.owners[o.name] = o.lease
+}
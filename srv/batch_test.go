@@ -0,0 +1,160 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/florianl/go-tc"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv/backend"
+	"github.com/openconfig/aite/srv/backend/fake"
+)
+
+// failingBackend is a fake backend that fails to install qdiscs on the link with
+// index failIndex, and fails to list qdiscs if failList is set.
+type failingBackend struct {
+	*fake.Backend
+	failIndex int
+	failList  bool
+}
+
+func (f *failingBackend) QdiscReplace(msg tc.Msg, kind string, options []byte) error {
+	if int(msg.Ifindex) == f.failIndex {
+		return unix.ENOMEM
+	}
+	return f.Backend.QdiscReplace(msg, kind, options)
+}
+
+func (f *failingBackend) QdiscList() ([]*backend.Qdisc, error) {
+	if f.failList {
+		return nil, errors.New("dump interrupted")
+	}
+	return f.Backend.QdiscList()
+}
+
+func TestBatchSetInterfacesRollback(t *testing.T) {
+	s, b := newFakeServer(t)
+	fb := &failingBackend{Backend: b, failIndex: b.AddLink("eth2", true)}
+	s.backend = fb
+
+	// eth0 is changed under a lease, and eth1 without one, before the batch.
+	l, err := s.newLease()
+	if err != nil {
+		t.Fatalf("cannot create lease, %v", err)
+	}
+	eth0 := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10, Direction: apb.Direction_DIR_EGRESS}
+	eth1 := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LossPct: 5, Direction: apb.Direction_DIR_EGRESS}
+	mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth0", Params: eth0, LeaseId: l.id})
+	mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: eth1})
+	leaseSnap := l.snaps["eth0"]
+
+	impaired := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50, Direction: apb.Direction_DIR_BOTH}
+	_, err = s.BatchSetInterfaces(context.Background(), &apb.BatchSetInterfacesRequest{
+		Requests: []*apb.SetInterfaceRequest{
+			{Name: "eth0", Params: impaired},
+			{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}},
+			{Name: "eth2", Params: impaired},
+		},
+	})
+	if got := status.Code(err); got != codes.Aborted {
+		t.Fatalf("BatchSetInterfaces(): did not get expected error code, got: %s (%v), want: %s", got, err, codes.Aborted)
+	}
+
+	wantParams(t, s, "eth0", eth0)
+	wantParams(t, s, "eth1", eth1)
+	wantParams(t, s, "eth2", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+	for _, name := range []string{"eth0", "eth1", "eth2"} {
+		if to, ok := b.Redirect(name); ok {
+			t.Errorf("BatchSetInterfaces(): ingress traffic of %s still redirected to %s", name, to)
+		}
+	}
+
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	if owner := s.owners["eth0"]; owner != l {
+		t.Errorf("BatchSetInterfaces(): eth0 not returned to its lease, got owner: %v", owner)
+	}
+	if snap := l.snaps["eth0"]; snap != leaseSnap {
+		t.Errorf("BatchSetInterfaces(): lease does not hold its original snapshot of eth0, got: %+v, want: %+v", snap, leaseSnap)
+	}
+	if owner, ok := s.owners["eth1"]; ok {
+		t.Errorf("BatchSetInterfaces(): eth1 owned by lease %s after rollback", owner.id)
+	}
+}
+
+func TestBatchSetInterfacesErrors(t *testing.T) {
+	up := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP}
+
+	tests := []struct {
+		desc string
+		in   *apb.BatchSetInterfacesRequest
+		// failList indicates that the backend fails to list qdiscs.
+		failList bool
+		wantCode codes.Code
+	}{{
+		desc:     "no requests",
+		in:       &apb.BatchSetInterfacesRequest{},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "invalid request",
+		in: &apb.BatchSetInterfacesRequest{Requests: []*apb.SetInterfaceRequest{
+			{Name: "eth0", Params: up},
+			{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LossPct: 101}},
+		}},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "interface specified more than once",
+		in: &apb.BatchSetInterfacesRequest{Requests: []*apb.SetInterfaceRequest{
+			{Name: "eth1", Params: up},
+			{Name: "eth1", Params: up},
+		}},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "inactive lease",
+		in: &apb.BatchSetInterfacesRequest{Requests: []*apb.SetInterfaceRequest{
+			{Name: "eth1", Params: up, LeaseId: "expired"},
+		}},
+		wantCode: codes.FailedPrecondition,
+	}, {
+		desc:     "state cannot be recorded",
+		in:       &apb.BatchSetInterfacesRequest{Requests: []*apb.SetInterfaceRequest{{Name: "eth1", Params: up}}},
+		failList: true,
+		wantCode: codes.Internal,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, b := newFakeServer(t)
+			s.backend = &failingBackend{Backend: b, failList: tt.failList}
+			_, err := s.BatchSetInterfaces(context.Background(), tt.in)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("BatchSetInterfaces(): did not get expected error code, got: %s (%v), want: %s", got, err, tt.wantCode)
+			}
+			for _, name := range []string{"eth0", "eth1"} {
+				if q := b.Qdiscs(name); len(q) != 0 {
+					t.Errorf("BatchSetInterfaces(): %s changed by batch that was not applied, got qdiscs: %v", name, q)
+				}
+			}
+		})
+	}
+}
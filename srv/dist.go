@@ -0,0 +1,108 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"math"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+const (
+	// distTableSize is the number of buckets used when inverting the normal
+	// distribution's CDF, as per iproute2's netem table generators.
+	distTableSize = 16384
+	// distScale is the factor by which values in a netem distribution table are
+	// scaled, NETEM_DIST_SCALE in include/uapi/linux/pkt_sched.h.
+	distScale = 8192
+	// paretoShape is the shape parameter of the pareto distribution used by netem.
+	paretoShape = 3.0
+)
+
+// distTables contains the netem delay distribution table for each distribution
+// that requires one. The tables are the equivalent of the normal, pareto and
+// paretonormal tables that iproute2 installs to /usr/lib/tc, such that Aite does
+// not depend on them being present in the container.
+var distTables = map[apb.DelayDistribution][]int16{
+	apb.DelayDistribution_DD_NORMAL:       normalTable(),
+	apb.DelayDistribution_DD_PARETO:       paretoTable(),
+	apb.DelayDistribution_DD_PARETONORMAL: paretoNormalTable(),
+}
+
+// inverseNormal returns the inverse of the CDF of the standard normal distribution,
+// sampled at distTableSize points, as generated by iproute2's netem/normal.c.
+func inverseNormal() []float64 {
+	table := make([]float64, distTableSize+1)
+	for x := -10.0; x < 10.05; x += .00005 {
+		i := int(math.RoundToEven(distTableSize * (.5 + .5*math.Erf(x/math.Sqrt2))))
+		table[i] = x
+	}
+	return table
+}
+
+// normalTable returns the netem distribution table for the normal distribution.
+func normalTable() []int16 {
+	inv := inverseNormal()
+	table := []int16{}
+	for i := 0; i < distTableSize; i += 4 {
+		table = append(table, distValue(inv[i]*distScale))
+	}
+	return table
+}
+
+// paretoValue returns the value of the pareto distribution for the point i of
+// 65536, scaled for use in a netem distribution table.
+func paretoValue(i int) float64 {
+	v := float64(i) / 65536.0
+	v = 1.0 / math.Pow(v, 1.0/paretoShape)
+	v -= 1.5
+	return v * (4.0 / 3.0) * distScale
+}
+
+// paretoTable returns the netem distribution table for the pareto distribution,
+// as generated by iproute2's netem/pareto.c.
+func paretoTable() []int16 {
+	table := []int16{}
+	for i := 65536; i > 0; i -= 16 {
+		table = append(table, distValue(paretoValue(i)))
+	}
+	return table
+}
+
+// paretoNormalTable returns the netem distribution table for a distribution that
+// is 3/4 normal and 1/4 pareto, as generated by iproute2's netem/paretonormal.c.
+func paretoNormalTable() []int16 {
+	inv := inverseNormal()
+	table := []int16{}
+	for i := 0; i < distTableSize; i += 4 {
+		normal := int(distValue(inv[i] * distScale))
+		pareto := int(distValue(paretoValue(65536 - 4*i)))
+		table = append(table, distValue(float64((3*normal+pareto)/4)))
+	}
+	return table
+}
+
+// distValue rounds v to the nearest value that can be stored in a netem
+// distribution table.
+func distValue(v float64) int16 {
+	v = math.RoundToEven(v)
+	switch {
+	case v > math.MaxInt16:
+		return math.MaxInt16
+	case v < math.MinInt16:
+		return math.MinInt16
+	}
+	return int16(v)
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"net"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// FlapInterface implements the FlapInterface RPC for the Aite service. It flaps
+// the administrative state of the interface according to the schedule specified
+// in the request, sending each transition to the client. When the RPC returns, the
+// interface is returned to the administrative state that it was in before the flap.
+// Since the flap supersedes earlier changes to the interface, any pending revert of
+// the interface is cancelled, and it is released from any lease under which it was
+// changed. Requests to change the state of the interface are rejected whilst it is
+// being flapped.
+func (s *S) FlapInterface(req *apb.FlapInterfaceRequest, stream apb.Aite_FlapInterfaceServer) error {
+	if !s.validInterface(req.Name) {
+		return status.Errorf(codes.InvalidArgument, "invalid interface name specified, %s", req.Name)
+	}
+
+	if req.DownMsec == 0 || req.UpMsec == 0 {
+		return status.Errorf(codes.InvalidArgument, "down and up durations must be specified, got down: %d msec, up: %d msec", req.DownMsec, req.UpMsec)
+	}
+
+	s.changeMu.Lock()
+	if err := s.checkNotFlapping(req.Name); err != nil {
+		s.changeMu.Unlock()
+		return err
+	}
+	attrs, err := s.backend.LinkByName(req.Name)
+	if err != nil {
+		s.changeMu.Unlock()
+		return status.Errorf(codes.Internal, "cannot find interface %s, %v", req.Name, err)
+	}
+	adminUp := attrs.Flags&net.FlagUp != 0
+	s.cancelRevert(req.Name)
+	s.releaseInterface(req.Name)
+	s.flapping[req.Name] = true
+	s.changeMu.Unlock()
+
+	defer func() {
+		s.changeMu.Lock()
+		defer s.changeMu.Unlock()
+		delete(s.flapping, req.Name)
+		if err := s.backend.LinkSetState(req.Name, adminUp); err != nil {
+			klog.Errorf("cannot restore administrative state of interface %s after flapping, %v", req.Name, err)
+		}
+	}()
+
+	ctx := stream.Context()
+	transitions := []struct {
+		state apb.InterfaceState
+		up    bool
+		hold  time.Duration
+	}{
+		{apb.InterfaceState_IS_ADMIN_DOWN, false, time.Duration(req.DownMsec) * time.Millisecond},
+		{apb.InterfaceState_IS_UP, true, time.Duration(req.UpMsec) * time.Millisecond},
+	}
+
+	for i := uint32(1); req.Count == 0 || i <= req.Count; i++ {
+		for _, t := range transitions {
+			if err := s.setFlapState(req.Name, t.up); err != nil {
+				return status.Errorf(codes.Internal, "cannot set interface state, %v", err)
+			}
+
+			if err := stream.Send(&apb.FlapInterfaceResponse{
+				Name:      req.Name,
+				State:     t.state,
+				Iteration: i,
+				Timestamp: time.Now().UnixNano(),
+			}); err != nil {
+				return err
+			}
+
+			// The interface is not held up after the final flap, such that the
+			// RPC completes as soon as the interface has been restored.
+			if req.Count != 0 && i == req.Count && t.state == apb.InterfaceState_IS_UP {
+				break
+			}
+
+			timer := time.NewTimer(t.hold)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return status.FromContextError(ctx.Err()).Err()
+			case <-timer.C:
+			}
+		}
+	}
+	return nil
+}
+
+// setFlapState sets the interface with the specified name to be administratively up
+// or down, serialised with other requests that change the state of interfaces.
+func (s *S) setFlapState(name string, up bool) error {
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	return s.backend.LinkSetState(name, up)
+}
+
+// checkNotFlapping returns an error if the interface with the specified name is
+// being flapped, such that it cannot otherwise be changed. It must be called with
+// changeMu held.
+func (s *S) checkNotFlapping(name string) error {
+	if s.flapping[name] {
+		return status.Errorf(codes.FailedPrecondition, "interface %s is being flapped", name)
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// isFlapping reports whether s is flapping the interface with the specified name.
+func isFlapping(s *S, name string) bool {
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	return s.flapping[name]
+}
+
+// transition is a single transition of an interface that is being flapped.
+type transition struct {
+	state     apb.InterfaceState
+	iteration uint32
+}
+
+func TestFlapInterface(t *testing.T) {
+	up := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP}
+	adminDown := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}
+
+	tests := []struct {
+		desc string
+		// initial is the state to which the interface is set before the flap,
+		// nil if it is not set.
+		initial *apb.InterfaceStateParams
+		in      *apb.FlapInterfaceRequest
+		want    []transition
+		// wantAfter is the state of the interface once the flap completes.
+		wantAfter *apb.InterfaceStateParams
+	}{{
+		desc: "single flap",
+		in:   &apb.FlapInterfaceRequest{Name: "eth1", DownMsec: 1, UpMsec: 1, Count: 1},
+		want: []transition{
+			{apb.InterfaceState_IS_ADMIN_DOWN, 1},
+			{apb.InterfaceState_IS_UP, 1},
+		},
+		wantAfter: up,
+	}, {
+		desc: "multiple flaps",
+		in:   &apb.FlapInterfaceRequest{Name: "eth1", DownMsec: 1, UpMsec: 1, Count: 3},
+		want: []transition{
+			{apb.InterfaceState_IS_ADMIN_DOWN, 1},
+			{apb.InterfaceState_IS_UP, 1},
+			{apb.InterfaceState_IS_ADMIN_DOWN, 2},
+			{apb.InterfaceState_IS_UP, 2},
+			{apb.InterfaceState_IS_ADMIN_DOWN, 3},
+			{apb.InterfaceState_IS_UP, 3},
+		},
+		wantAfter: up,
+	}, {
+		desc:    "administratively down before flap",
+		initial: adminDown,
+		in:      &apb.FlapInterfaceRequest{Name: "eth1", DownMsec: 1, UpMsec: 1, Count: 2},
+		want: []transition{
+			{apb.InterfaceState_IS_ADMIN_DOWN, 1},
+			{apb.InterfaceState_IS_UP, 1},
+			{apb.InterfaceState_IS_ADMIN_DOWN, 2},
+			{apb.InterfaceState_IS_UP, 2},
+		},
+		wantAfter: adminDown,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, _ := newFakeServer(t)
+			c := newFakeClient(t, s)
+			if tt.initial != nil {
+				mustSet(t, s, &apb.SetInterfaceRequest{Name: tt.in.Name, Params: tt.initial})
+			}
+
+			stream, err := c.FlapInterface(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("FlapInterface(): cannot start flap, %v", err)
+			}
+			var got []transition
+			var last int64
+			for {
+				resp, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("FlapInterface(): did not complete flap, %v", err)
+				}
+				if resp.GetName() != tt.in.Name {
+					t.Errorf("FlapInterface(): did not get expected interface name, got: %s, want: %s", resp.GetName(), tt.in.Name)
+				}
+				if resp.GetTimestamp() < last {
+					t.Errorf("FlapInterface(): transitions not in order, got timestamp: %d, after: %d", resp.GetTimestamp(), last)
+				}
+				last = resp.GetTimestamp()
+				got = append(got, transition{resp.GetState(), resp.GetIteration()})
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("FlapInterface(): did not get expected transitions, got: %v, want: %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("FlapInterface(): did not get expected transition %d, got: %v, want: %v", i, got[i], tt.want[i])
+				}
+			}
+			waitFor(t, "flap to complete", func() bool { return !isFlapping(s, tt.in.Name) })
+			wantParams(t, s, tt.in.Name, tt.wantAfter)
+		})
+	}
+}
+
+func TestFlapInterfaceErrors(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       *apb.FlapInterfaceRequest
+		wantCode codes.Code
+	}{{
+		desc:     "no interface",
+		in:       &apb.FlapInterfaceRequest{DownMsec: 1, UpMsec: 1},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "no down duration",
+		in:       &apb.FlapInterfaceRequest{Name: "eth1", UpMsec: 1},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "no up duration",
+		in:       &apb.FlapInterfaceRequest{Name: "eth1", DownMsec: 1},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "unknown interface",
+		in:       &apb.FlapInterfaceRequest{Name: "eth9", DownMsec: 1, UpMsec: 1},
+		wantCode: codes.InvalidArgument,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, _ := newFakeServer(t)
+			stream, err := newFakeClient(t, s).FlapInterface(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("FlapInterface(): cannot start flap, %v", err)
+			}
+			if _, err := stream.Recv(); status.Code(err) != tt.wantCode {
+				t.Fatalf("FlapInterface(): did not get expected error code, got: %s (%v), want: %s", status.Code(err), err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestFlapInterfaceRejectsChanges(t *testing.T) {
+	s, _ := newFakeServer(t)
+	c := newFakeClient(t, s)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The interface is held down for longer than the test, such that it is
+	// flapping until the flap is cancelled.
+	stream, err := c.FlapInterface(ctx, &apb.FlapInterfaceRequest{Name: "eth1", DownMsec: 3600000, UpMsec: 1})
+	if err != nil {
+		t.Fatalf("FlapInterface(): cannot start flap, %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("FlapInterface(): did not get first transition, %v", err)
+	}
+
+	impaired := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}
+	if _, err := s.SetInterface(context.Background(), &apb.SetInterfaceRequest{Name: "eth1", Params: impaired}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("SetInterface(): did not get expected error for flapping interface, got: %v, want code: %s", err, codes.FailedPrecondition)
+	}
+	if _, err := s.BatchSetInterfaces(context.Background(), &apb.BatchSetInterfacesRequest{Requests: []*apb.SetInterfaceRequest{
+		{Name: "eth0", Params: impaired},
+		{Name: "eth1", Params: impaired},
+	}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("BatchSetInterfaces(): did not get expected error for flapping interface, got: %v, want code: %s", err, codes.FailedPrecondition)
+	}
+	wantParams(t, s, "eth0", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+
+	second, err := c.FlapInterface(context.Background(), &apb.FlapInterfaceRequest{Name: "eth1", DownMsec: 1, UpMsec: 1, Count: 1})
+	if err != nil {
+		t.Fatalf("FlapInterface(): cannot start flap, %v", err)
+	}
+	if _, err := second.Recv(); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("FlapInterface(): did not get expected error for flapping interface, got: %v, want code: %s", err, codes.FailedPrecondition)
+	}
+
+	// Cancelling the flap restores the interface, after which it can be changed.
+	cancel()
+	waitFor(t, "flap to be cancelled", func() bool { return !isFlapping(s, "eth1") })
+	wantParams(t, s, "eth1", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+	mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: impaired})
+}
+
+func TestFlapInterfaceSupersedes(t *testing.T) {
+	s, _ := newFakeServer(t)
+	c := newFakeClient(t, s)
+	l, err := s.newLease()
+	if err != nil {
+		t.Fatalf("cannot create lease, %v", err)
+	}
+	adminDown := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}
+	mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: adminDown, LeaseId: l.id, DurationMsec: 50})
+
+	stream, err := c.FlapInterface(context.Background(), &apb.FlapInterfaceRequest{Name: "eth1", DownMsec: 1, UpMsec: 1, Count: 1})
+	if err != nil {
+		t.Fatalf("FlapInterface(): cannot start flap, %v", err)
+	}
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("FlapInterface(): did not complete flap, %v", err)
+	}
+	waitFor(t, "flap to complete", func() bool { return !isFlapping(s, "eth1") })
+
+	if n := pendingReverts(s); n != 0 {
+		t.Errorf("FlapInterface(): pending revert not cancelled, got: %d reverts", n)
+	}
+	s.changeMu.Lock()
+	owner := s.owners["eth1"]
+	s.changeMu.Unlock()
+	if owner != nil {
+		t.Errorf("FlapInterface(): eth1 not released from lease, got owner: %s", owner.id)
+	}
+
+	// Neither the superseded revert nor the end of the lease restores the state
+	// of the interface before it was changed.
+	time.Sleep(150 * time.Millisecond)
+	if err := s.releaseLease(l.id); err != nil {
+		t.Fatalf("cannot release lease, %v", err)
+	}
+	wantParams(t, s, "eth1", adminDown)
+}
@@ -0,0 +1,329 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/vishvananda/netlink/nl"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv/backend"
+)
+
+// Where flows are impaired, a prio qdisc is installed at the root of the device in
+// place of a single netem qdisc. The first band of the prio qdisc contains a netem
+// qdisc applying the impairments to packets that do not match any flow, and each
+// subsequent band contains a netem qdisc applying the impairments of a flow. flower
+// filters attached to the prio qdisc steer the packets of each flow to its band.
+
+// maxFlows is the maximum number of flows that can be impaired on a device, such
+// that the bands of the prio qdisc do not exceed TCQ_PRIO_BANDS.
+const maxFlows = 15
+
+// flowRootHandle is the handle of the prio qdisc installed at the root of a
+// device on which flows are impaired.
+var flowRootHandle = core.BuildHandle(0x1, 0x0)
+
+// flowQdisc is the netem qdisc that impairs a flow.
+type flowQdisc struct {
+	// flow is the flow as requested.
+	flow *apb.FlowImpairment
+	// opts is the encoded options of the netem qdisc that impairs the flow.
+	opts []byte
+}
+
+// isImpairment reports whether q is a root qdisc that was installed by Aite to
+// impair a device, either a netem qdisc or the prio qdisc used to impair flows.
+func isImpairment(q *qdisc) bool {
+	return q != nil && (q.Kind == "netem" || (q.Kind == "prio" && q.Handle == flowRootHandle))
+}
+
+// bandClass returns the class of the band with the specified index in the prio
+// qdisc installed by Aite. Band 0 impairs packets that do not match any flow, and
+// band i impairs flow i-1.
+func bandClass(band int) uint32 {
+	return core.BuildHandle(0x1, uint32(band+1))
+}
+
+// validateMatch checks that the flow match m can be programmed. A nil match matches
+// all packets.
+func validateMatch(m *apb.FlowMatch) error {
+	if m == nil {
+		return nil
+	}
+
+	var family int
+	for _, p := range []string{m.GetSrcPrefix(), m.GetDstPrefix()} {
+		if p == "" {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(p)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid prefix %s, %v", p, err)
+		}
+		f := unix.AF_INET6
+		if ip.To4() != nil {
+			f = unix.AF_INET
+		}
+		if family != 0 && f != family {
+			return status.Errorf(codes.InvalidArgument, "source and destination prefixes must be of the same address family")
+		}
+		family = f
+	}
+
+	if m.GetIpProtocol() > 255 {
+		return status.Errorf(codes.InvalidArgument, "IP protocol must be 0 <= protocol <= 255, got: %d", m.GetIpProtocol())
+	}
+
+	if m.GetSrcPort() != 0 || m.GetDstPort() != 0 {
+		switch m.GetIpProtocol() {
+		case unix.IPPROTO_TCP, unix.IPPROTO_UDP, unix.IPPROTO_SCTP:
+		default:
+			return status.Errorf(codes.InvalidArgument, "ports can only be matched for TCP, UDP or SCTP, got protocol: %d", m.GetIpProtocol())
+		}
+		if m.GetSrcPort() > 65535 || m.GetDstPort() > 65535 {
+			return status.Errorf(codes.InvalidArgument, "ports must be 0 < port <= 65535, got source: %d, destination: %d", m.GetSrcPort(), m.GetDstPort())
+		}
+	}
+
+	if m.Dscp != nil && m.GetDscp() > 63 {
+		return status.Errorf(codes.InvalidArgument, "DSCP must be 0 <= dscp <= 63, got: %d", m.GetDscp())
+	}
+
+	if m.GetVlanId() > 4094 {
+		return status.Errorf(codes.InvalidArgument, "VLAN ID must be 0 < id <= 4094, got: %d", m.GetVlanId())
+	}
+	return nil
+}
+
+// replaceFlows installs a prio qdisc at the root of the device with the specified name
+// and index, with a netem qdisc with the encoded options opts applied to packets that do
+// not match any of the flows, and the netem qdisc of each flow applied to its packets.
+// dist is the delay distribution whose table is included in opts. It must be called
+// with mu held.
+func (s *S) replaceFlows(name string, index int, opts []byte, dist apb.DelayDistribution, flows []*flowQdisc) error {
+	roots, err := s.rootQdiscs()
+	if err != nil {
+		return fmt.Errorf("cannot list qdiscs, %v", err)
+	}
+
+	// The qdiscs are rebuilt rather than changed, such that no distribution table
+	// or filter is retained from those previously installed.
+	if root := roots[uint32(index)]; isImpairment(root) {
+		klog.Infof("removing %s qdisc from device %s", root.Kind, name)
+		if err := s.backend.QdiscDel(root.Msg); err != nil {
+			return err
+		}
+	}
+
+	prio := &bytes.Buffer{}
+	// All packets that are not steered by a filter are placed into band 0.
+	if err := binary.Write(prio, nl.NativeEndian(), tc.Prio{Bands: uint32(len(flows) + 1)}); err != nil {
+		return fmt.Errorf("cannot encode prio options, %v", err)
+	}
+	rootMsg := tc.Msg{
+		Family:  unix.AF_UNSPEC,
+		Ifindex: uint32(index),
+		Handle:  flowRootHandle,
+		Parent:  tc.HandleRoot,
+	}
+	klog.Infof("installing prio qdisc with %d flows on device %s", len(flows), name)
+	if err := s.backend.QdiscReplace(rootMsg, "prio", prio.Bytes()); err != nil {
+		return err
+	}
+
+	bandOpts := [][]byte{opts}
+	for _, f := range flows {
+		bandOpts = append(bandOpts, f.opts)
+	}
+	for band, o := range bandOpts {
+		msg := tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: uint32(index),
+			Handle:  core.BuildHandle(uint32(band+0x10), 0x0),
+			Parent:  bandClass(band),
+		}
+		if err := s.backend.QdiscReplace(msg, "netem", o); err != nil {
+			return fmt.Errorf("cannot install netem qdisc in band %d, %v", band, err)
+		}
+	}
+
+	programmed := []*apb.FlowImpairment{}
+	for i, f := range flows {
+		if err := s.addFlowFilters(index, uint16(i+1), bandClass(i+1), f.flow.GetMatch()); err != nil {
+			return fmt.Errorf("cannot install filter for flow %d, %v", i, err)
+		}
+		programmed = append(programmed, proto.Clone(f.flow).(*apb.FlowImpairment))
+	}
+
+	st := s.programmed[name]
+	st.delayDist = dist
+	st.flows = programmed
+	s.programmed[name] = st
+
+	return nil
+}
+
+// addFlowFilters attaches flower filters to the prio qdisc installed by Aite on the
+// device with the specified index, steering packets that match m to classID. The
+// filters are installed with the specified priority, such that flows are matched in
+// order. Where m does not specify an address family but matches IP fields, filters
+// are installed for both IPv4 and IPv6.
+func (s *S) addFlowFilters(index int, prio uint16, classID uint32, m *apb.FlowMatch) error {
+	if m == nil {
+		m = &apb.FlowMatch{}
+	}
+
+	var families []uint16
+	switch p := m.GetSrcPrefix() + m.GetDstPrefix(); {
+	case p != "":
+		ip, _, _ := net.ParseCIDR(m.GetSrcPrefix())
+		if ip == nil {
+			ip, _, _ = net.ParseCIDR(m.GetDstPrefix())
+		}
+		families = []uint16{unix.ETH_P_IPV6}
+		if ip.To4() != nil {
+			families = []uint16{unix.ETH_P_IP}
+		}
+	case m.GetIpProtocol() != 0 || m.Dscp != nil:
+		families = []uint16{unix.ETH_P_IP, unix.ETH_P_IPV6}
+	default:
+		// No IP fields are matched, so the filter matches all packets, or all
+		// packets in a VLAN.
+		families = []uint16{0}
+	}
+
+	for _, f := range families {
+		if err := s.addFlowerFilter(index, prio, classID, f, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addFlowerFilter attaches a single flower filter steering packets of the specified
+// ethertype that match m to classID. An ethertype of zero matches packets of all
+// ethertypes. The tc package does not support matching IPv6 addresses, and hence
+// the attributes of the filter are encoded here.
+func (s *S) addFlowerFilter(index int, prio uint16, classID uint32, ethType uint16, m *apb.FlowMatch) error {
+	var opts []byte
+	add := func(attrType int, v []byte) {
+		opts = append(opts, nl.NewRtAttr(attrType, v).Serialize()...)
+	}
+	add(nl.TCA_FLOWER_CLASSID, nl.Uint32Attr(classID))
+
+	// The protocol of the filter is the outermost ethertype of the packet, which
+	// is that of the VLAN tag for packets within a VLAN.
+	protocol := ethType
+	switch {
+	case m.GetVlanId() != 0:
+		protocol = unix.ETH_P_8021Q
+		add(nl.TCA_FLOWER_KEY_ETH_TYPE, nl.Uint16Attr(backend.Htons(protocol)))
+		add(nl.TCA_FLOWER_KEY_VLAN_ID, nl.Uint16Attr(uint16(m.GetVlanId())))
+		if ethType != 0 {
+			add(nl.TCA_FLOWER_KEY_VLAN_ETH_TYPE, nl.Uint16Attr(backend.Htons(ethType)))
+		}
+	case ethType != 0:
+		add(nl.TCA_FLOWER_KEY_ETH_TYPE, nl.Uint16Attr(backend.Htons(ethType)))
+	default:
+		protocol = unix.ETH_P_ALL
+	}
+
+	if ethType != 0 {
+		if p := m.GetIpProtocol(); p != 0 {
+			add(nl.TCA_FLOWER_KEY_IP_PROTO, nl.Uint8Attr(uint8(p)))
+		}
+		for _, a := range []struct {
+			prefix           string
+			v4, v4m, v6, v6m int
+		}{
+			{m.GetSrcPrefix(), nl.TCA_FLOWER_KEY_IPV4_SRC, nl.TCA_FLOWER_KEY_IPV4_SRC_MASK, nl.TCA_FLOWER_KEY_IPV6_SRC, nl.TCA_FLOWER_KEY_IPV6_SRC_MASK},
+			{m.GetDstPrefix(), nl.TCA_FLOWER_KEY_IPV4_DST, nl.TCA_FLOWER_KEY_IPV4_DST_MASK, nl.TCA_FLOWER_KEY_IPV6_DST, nl.TCA_FLOWER_KEY_IPV6_DST_MASK},
+		} {
+			if a.prefix == "" {
+				continue
+			}
+			_, n, err := net.ParseCIDR(a.prefix)
+			if err != nil {
+				return err
+			}
+			if ip := n.IP.To4(); ip != nil {
+				add(a.v4, ip)
+				add(a.v4m, []byte(net.IP(n.Mask).To4()))
+				continue
+			}
+			add(a.v6, n.IP.To16())
+			add(a.v6m, []byte(n.Mask))
+		}
+
+		var src, dst int
+		switch m.GetIpProtocol() {
+		case unix.IPPROTO_TCP:
+			src, dst = nl.TCA_FLOWER_KEY_TCP_SRC, nl.TCA_FLOWER_KEY_TCP_DST
+		case unix.IPPROTO_UDP:
+			src, dst = nl.TCA_FLOWER_KEY_UDP_SRC, nl.TCA_FLOWER_KEY_UDP_DST
+		case unix.IPPROTO_SCTP:
+			src, dst = nl.TCA_FLOWER_KEY_SCTP_SRC, nl.TCA_FLOWER_KEY_SCTP_DST
+		}
+		if p := m.GetSrcPort(); p != 0 {
+			add(src, nl.Uint16Attr(backend.Htons(uint16(p))))
+		}
+		if p := m.GetDstPort(); p != 0 {
+			add(dst, nl.Uint16Attr(backend.Htons(uint16(p))))
+		}
+
+		// The DSCP is the upper six bits of the IPv4 TOS or IPv6 traffic class.
+		if m.Dscp != nil {
+			add(nl.TCA_FLOWER_KEY_IP_TOS, nl.Uint8Attr(uint8(m.GetDscp()<<2)))
+			add(nl.TCA_FLOWER_KEY_IP_TOS_MASK, nl.Uint8Attr(0xfc))
+		}
+	}
+
+	// The protocol is held in network byte order within the Info of the filter.
+	return s.backend.FilterAdd(tc.Msg{
+		Family:  unix.AF_UNSPEC,
+		Ifindex: uint32(index),
+		Parent:  flowRootHandle,
+		Info:    core.BuildHandle(uint32(prio), uint32(backend.Htons(protocol))),
+	}, "flower", opts)
+}
+
+// flowParams returns the impairments applied to each of the flows that Aite
+// programmed on a device, as described by st. tree contains the qdiscs installed
+// on the device, keyed by the handle of their parent.
+func flowParams(tree map[uint32]*qdisc, st ifState) []*apb.FlowImpairment {
+	flows := []*apb.FlowImpairment{}
+	for i, f := range st.flows {
+		p := &apb.InterfaceStateParams{}
+		decodeNetem(p, tree[bandClass(i+1)], f.GetParams().GetDelayDistribution())
+		flows = append(flows, &apb.FlowImpairment{
+			Match:  proto.Clone(f.GetMatch()).(*apb.FlowMatch),
+			Params: p,
+		})
+	}
+	return flows
+}
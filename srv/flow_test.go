@@ -0,0 +1,152 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"testing"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/vishvananda/netlink/nl"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv/backend"
+	"github.com/openconfig/aite/srv/backend/fake"
+)
+
+// filterKey is the priority, protocol and class of a flower filter.
+type filterKey struct {
+	prio     uint32
+	protocol uint16
+	classID  uint32
+}
+
+// decodeFilter returns the key of the flower filter f.
+func decodeFilter(t *testing.T, f *fake.Filter) filterKey {
+	t.Helper()
+	if f.Kind != "flower" || f.Parent != flowRootHandle {
+		t.Fatalf("did not get flower filter attached to prio qdisc, got: kind %s, parent %x", f.Kind, f.Parent)
+	}
+	prio, protocol := core.SplitHandle(f.Info)
+	k := filterKey{
+		prio:     prio,
+		protocol: backend.Htons(uint16(protocol)),
+	}
+	attrs, err := nl.ParseRouteAttr(f.Options)
+	if err != nil {
+		t.Fatalf("cannot parse filter options, %v", err)
+	}
+	for _, a := range attrs {
+		if a.Attr.Type == nl.TCA_FLOWER_CLASSID {
+			k.classID = nl.NativeEndian().Uint32(a.Value)
+		}
+	}
+	return k
+}
+
+func TestFlows(t *testing.T) {
+	flows := []*apb.FlowImpairment{{
+		Match:  &apb.FlowMatch{IpProtocol: unix.IPPROTO_UDP, DstPort: 53},
+		Params: &apb.InterfaceStateParams{LatencyMsec: 100},
+	}, {
+		Match:  &apb.FlowMatch{SrcPrefix: "2001:db8::/32"},
+		Params: &apb.InterfaceStateParams{LossPct: 5},
+	}, {
+		Match:  &apb.FlowMatch{VlanId: 10},
+		Params: &apb.InterfaceStateParams{LatencyMsec: 5, JitterMsec: 1, DelayDistribution: apb.DelayDistribution_DD_NORMAL},
+	}, {
+		Params: &apb.InterfaceStateParams{DuplicatePct: 1},
+	}}
+
+	s, b := newFakeServer(t)
+	mustSet(t, s, &apb.SetInterfaceRequest{
+		Name:   "eth1",
+		Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10},
+		Flows:  flows,
+	})
+
+	qdiscs := b.Qdiscs("eth1")
+	root := qdiscs[tc.HandleRoot]
+	if root == nil || root.Kind != "prio" || root.Handle != flowRootHandle {
+		t.Fatalf("did not get prio root qdisc, got: %v", root)
+	}
+	if bands := nl.NativeEndian().Uint32(root.Options); bands != uint32(len(flows)+1) {
+		t.Errorf("did not get expected number of prio bands, got: %d, want: %d", bands, len(flows)+1)
+	}
+	latencies := []uint32{10, 100, 0, 5, 0}
+	for band, want := range latencies {
+		q := qdiscs[bandClass(band)]
+		if q == nil || q.Kind != "netem" {
+			t.Errorf("did not get netem qdisc in band %d, got: %v", band, q)
+			continue
+		}
+		n, err := unmarshalNetem(q.Options)
+		if err != nil {
+			t.Fatalf("cannot decode netem qdisc in band %d, %v", band, err)
+		}
+		if got := msec(n.Qopt.Latency); got != want {
+			t.Errorf("did not get expected latency in band %d, got: %d msec, want: %d msec", band, got, want)
+		}
+	}
+
+	// Flows matching IP fields without an address family are matched for both
+	// IPv4 and IPv6, and flows that match no IP fields match all protocols.
+	want := []filterKey{
+		{1, unix.ETH_P_IP, bandClass(1)},
+		{1, unix.ETH_P_IPV6, bandClass(1)},
+		{2, unix.ETH_P_IPV6, bandClass(2)},
+		{3, unix.ETH_P_8021Q, bandClass(3)},
+		{4, unix.ETH_P_ALL, bandClass(4)},
+	}
+	filters := b.Filters("eth1")
+	if len(filters) != len(want) {
+		t.Fatalf("did not get expected number of filters, got: %d, want: %d", len(filters), len(want))
+	}
+	for i, f := range filters {
+		if got := decodeFilter(t, f); got != want[i] {
+			t.Errorf("did not get expected filter %d, got: %+v, want: %+v", i, got, want[i])
+		}
+	}
+
+	got := mustGet(t, s, "eth1").GetFlows()
+	if len(got) != len(flows) {
+		t.Fatalf("GetInterface(): did not get expected number of flows, got: %d, want: %d", len(got), len(flows))
+	}
+	for i, f := range flows {
+		if !proto.Equal(got[i], f) {
+			t.Errorf("GetInterface(): did not get expected flow %d, got: %s, want: %s", i, prototext.Format(got[i]), prototext.Format(f))
+		}
+	}
+
+	// Impairing the interface without flows replaces the prio qdisc and its
+	// filters with a single netem qdisc.
+	mustSet(t, s, &apb.SetInterfaceRequest{
+		Name:   "eth1",
+		Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10},
+	})
+	if root := b.Qdiscs("eth1")[tc.HandleRoot]; root == nil || root.Kind != "netem" {
+		t.Errorf("did not get netem root qdisc after removing flows, got: %v", root)
+	}
+	if f := b.Filters("eth1"); len(f) != 0 {
+		t.Errorf("filters not removed with flows, got: %d filters", len(f))
+	}
+	if f := mustGet(t, s, "eth1").GetFlows(); len(f) != 0 {
+		t.Errorf("GetInterface(): flows not removed, got: %v", f)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+	"k8s.io/klog"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/vishvananda/netlink"
+
+	"github.com/openconfig/aite/srv/backend"
+)
+
+// A qdisc can only be attached to the egress of an interface. Thus, to impair the
+// traffic that is received by an interface, an ingress qdisc is attached to it with
+// a filter that redirects all received packets to the egress of an intermediate
+// functional block (ifb) device, which returns them to the interface's receive path
+// once they have traversed the netem qdisc installed on the ifb.
+
+// ifbPrefix is the prefix of the names of ifb devices created by Aite.
+const ifbPrefix = "aifb"
+
+// ifbName returns the name of the ifb device to which the ingress traffic of the
+// interface with the specified index is redirected. The index is used rather than
+// the name of the interface since interface names may already be the maximum length.
+func ifbName(index int) string {
+	return fmt.Sprintf("%s%d", ifbPrefix, index)
+}
+
+// ingressMsg returns the tc message describing the ingress qdisc of the interface
+// with the specified index.
+func ingressMsg(index int) tc.Msg {
+	return tc.Msg{
+		Family:  unix.AF_UNSPEC,
+		Ifindex: uint32(index),
+		Handle:  core.BuildHandle(0xFFFF, 0x0),
+		Parent:  tc.HandleIngress,
+	}
+}
+
+// ensureIngress redirects all traffic received by the interface with the specified
+// index to its ifb device, creating the device if it does not exist. It returns the
+// attributes of the ifb device. It must be called with mu held.
+func (s *S) ensureIngress(index int) (*netlink.LinkAttrs, error) {
+	name := ifbName(index)
+	switch _, err := s.backend.LinkByName(name); {
+	case errors.Is(err, backend.ErrLinkNotFound):
+		klog.Infof("creating ifb device %s for interface index %d", name, index)
+	case err != nil:
+		return nil, fmt.Errorf("cannot find ifb device %s, %v", name, err)
+	}
+
+	// The device is brought up even if it exists, since it may have been taken
+	// down since it was created.
+	if err := s.backend.LinkAddIfb(name); err != nil {
+		return nil, err
+	}
+
+	ifb, err := s.backend.LinkByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find ifb device %s, %v", name, err)
+	}
+
+	if err := s.backend.RedirectIngress(index, ifb.Index); err != nil {
+		return nil, fmt.Errorf("cannot redirect ingress traffic to %s, %v", name, err)
+	}
+
+	return ifb, nil
+}
+
+// removeIngress removes the redirection of traffic received by the interface with
+// the specified index, along with its ifb device. It is not an error for the
+// traffic not to be redirected. It must be called with mu held.
+func (s *S) removeIngress(index int) error {
+	name := ifbName(index)
+	switch _, err := s.backend.LinkByName(name); {
+	case errors.Is(err, backend.ErrLinkNotFound):
+		return nil
+	case err != nil:
+		return fmt.Errorf("cannot find ifb device %s, %v", name, err)
+	}
+
+	qdiscs, err := s.dumpQdiscs()
+	if err != nil {
+		return err
+	}
+	for _, q := range qdiscs {
+		if q.Ifindex == uint32(index) && q.Parent == tc.HandleIngress {
+			klog.Infof("removing ingress qdisc from interface index %d", index)
+			// Removing the ingress qdisc also removes the filters attached to it.
+			if err := s.backend.QdiscDel(ingressMsg(index)); err != nil {
+				return fmt.Errorf("cannot remove ingress qdisc, %v", err)
+			}
+		}
+	}
+
+	klog.Infof("removing ifb device %s", name)
+	if err := s.backend.LinkDel(name); err != nil {
+		return fmt.Errorf("cannot remove ifb device %s, %v", name, err)
+	}
+	delete(s.programmed, name)
+
+	return nil
+}
+
+// ingressTree returns the qdiscs installed on the ifb device of the interface with the
+// specified index from trees, which are keyed by interface index. It returns nil if
+// the interface's ingress traffic is not redirected.
+func (s *S) ingressTree(index int, trees map[uint32]map[uint32]*qdisc) (map[uint32]*qdisc, error) {
+	attrs, err := s.backend.LinkByName(ifbName(index))
+	switch {
+	case errors.Is(err, backend.ErrLinkNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("cannot find ifb device %s, %v", ifbName(index), err)
+	}
+	return trees[uint32(attrs.Index)], nil
+}
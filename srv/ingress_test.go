@@ -0,0 +1,100 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"net"
+	"testing"
+
+	"github.com/florianl/go-tc"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+func TestIngress(t *testing.T) {
+	tests := []struct {
+		desc      string
+		direction apb.Direction
+		// wantEgress and wantIngress indicate that the egress of the interface,
+		// and that of its ifb device, are impaired.
+		wantEgress  bool
+		wantIngress bool
+	}{{
+		desc:       "egress",
+		direction:  apb.Direction_DIR_EGRESS,
+		wantEgress: true,
+	}, {
+		desc:        "ingress",
+		direction:   apb.Direction_DIR_INGRESS,
+		wantIngress: true,
+	}, {
+		desc:        "both",
+		direction:   apb.Direction_DIR_BOTH,
+		wantEgress:  true,
+		wantIngress: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, b := newFakeServer(t)
+			eth, err := b.LinkByName("eth1")
+			if err != nil {
+				t.Fatalf("cannot find eth1, %v", err)
+			}
+			ifb := ifbName(eth.Index)
+
+			// The interface is first impaired in the opposite direction, such that
+			// changing direction removes the impairment that no longer applies.
+			for _, d := range []apb.Direction{apb.Direction_DIR_BOTH, tt.direction} {
+				mustSet(t, s, &apb.SetInterfaceRequest{
+					Name:   "eth1",
+					Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 20, Direction: d},
+				})
+			}
+
+			qdiscs := b.Qdiscs("eth1")
+			if root := qdiscs[tc.HandleRoot]; (root != nil && root.Kind == "netem") != tt.wantEgress {
+				t.Errorf("did not get expected egress impairment, got: %v, want impaired: %v", root, tt.wantEgress)
+			}
+
+			to, redirected := b.Redirect("eth1")
+			if redirected != tt.wantIngress || (redirected && to != ifb) {
+				t.Errorf("did not get expected redirection, got: %s (%v), want: %s (%v)", to, redirected, ifb, tt.wantIngress)
+			}
+			if q := qdiscs[tc.HandleIngress]; (q != nil && q.Kind == "ingress") != tt.wantIngress {
+				t.Errorf("did not get expected ingress qdisc, got: %v, want present: %v", q, tt.wantIngress)
+			}
+
+			attrs, err := b.LinkByName(ifb)
+			switch {
+			case !tt.wantIngress && err == nil:
+				t.Errorf("ifb device %s not removed", ifb)
+			case tt.wantIngress && err != nil:
+				t.Fatalf("cannot find ifb device %s, %v", ifb, err)
+			case tt.wantIngress:
+				if attrs.Flags&net.FlagUp == 0 {
+					t.Errorf("ifb device %s is not up", ifb)
+				}
+				if root := b.Qdiscs(ifb)[tc.HandleRoot]; root == nil || root.Kind != "netem" {
+					t.Errorf("did not get netem qdisc on ifb device, got: %v", root)
+				}
+			}
+
+			if got := mustGet(t, s, "eth1").GetParams(); got.GetDirection() != tt.direction || got.GetLatencyMsec() != 20 {
+				t.Errorf("GetInterface(): did not get expected impairment, got: direction %s, latency %d msec, want: direction %s, latency 20 msec", got.GetDirection(), got.GetLatencyMsec(), tt.direction)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/vishvananda/netlink"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// ListInterfaces implements the ListInterfaces RPC for the Aite service. It returns
+// each link within the network namespace along with its current state.
+func (s *S) ListInterfaces(ctx context.Context, _ *apb.ListInterfacesRequest) (*apb.ListInterfacesResponse, error) {
+	links, err := s.backend.LinkList()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list interfaces, %v", err)
+	}
+
+	qdiscs, err := s.rootQdiscs()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list qdiscs, %v", err)
+	}
+
+	resp := &apb.ListInterfacesResponse{}
+	for _, attrs := range links {
+		resp.Interfaces = append(resp.Interfaces, interfaceProto(attrs, qdiscs[uint32(attrs.Index)]))
+	}
+	return resp, nil
+}
+
+// GetInterface implements the GetInterface RPC for the Aite service. It reads the
+// current state of the interface, and the impairments applied to it, from the kernel.
+func (s *S) GetInterface(ctx context.Context, req *apb.GetInterfaceRequest) (*apb.GetInterfaceResponse, error) {
+	if !s.validInterface(req.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid interface name specified, %s", req.Name)
+	}
+
+	attrs, egress, ingress, err := s.linkState(req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get interface %s, %v", req.Name, err)
+	}
+
+	s.mu.Lock()
+	st := s.programmed[req.Name]
+	ingressSt := s.programmed[ifbName(attrs.Index)]
+	s.mu.Unlock()
+
+	// The same flows are impaired in each direction, and hence are read from the
+	// ingress qdiscs only where the egress is not impaired.
+	flows := flowParams(egress, st)
+	if defaultNetem(egress) == nil {
+		flows = flowParams(ingress, ingressSt)
+	}
+
+	return &apb.GetInterfaceResponse{
+		Interface: interfaceProto(attrs, egress[tc.HandleRoot]),
+		Params:    stateParams(attrs, egress, ingress, st, ingressSt),
+		Flows:     flows,
+	}, nil
+}
+
+// validInterface reports whether name is the name of an interface that exists.
+func (s *S) validInterface(name string) bool {
+	if name == "" {
+		return false
+	}
+	_, err := s.backend.LinkByName(name)
+	return err == nil
+}
+
+// linkState returns the attributes of the link with the specified name, along with
+// the qdiscs installed on it, and the qdiscs installed on the ifb device to which its
+// ingress traffic is redirected. The qdiscs of each device are keyed by the handle of
+// their parent, such that the root qdisc is keyed by tc.HandleRoot. The returned
+// ingress qdiscs are nil if the ingress traffic of the link is not redirected.
+func (s *S) linkState(name string) (*netlink.LinkAttrs, map[uint32]*qdisc, map[uint32]*qdisc, error) {
+	attrs, err := s.backend.LinkByName(name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	trees, err := s.qdiscTrees()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot list qdiscs, %v", err)
+	}
+
+	ingress, err := s.ingressTree(attrs.Index, trees)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return attrs, trees[uint32(attrs.Index)], ingress, nil
+}
+
+// qdiscTrees returns the qdiscs installed on each interface in the namespace, keyed
+// by the index of the interface, and then by the handle of their parent.
+func (s *S) qdiscTrees() (map[uint32]map[uint32]*qdisc, error) {
+	qdiscs, err := s.dumpQdiscs()
+	if err != nil {
+		return nil, err
+	}
+
+	trees := map[uint32]map[uint32]*qdisc{}
+	for _, q := range qdiscs {
+		if trees[q.Ifindex] == nil {
+			trees[q.Ifindex] = map[uint32]*qdisc{}
+		}
+		trees[q.Ifindex][q.Parent] = q
+	}
+	return trees, nil
+}
+
+// rootQdiscs returns the qdiscs installed at the root of each interface in the
+// namespace, keyed by the index of the interface.
+func (s *S) rootQdiscs() (map[uint32]*qdisc, error) {
+	qdiscs, err := s.dumpQdiscs()
+	if err != nil {
+		return nil, err
+	}
+
+	roots := map[uint32]*qdisc{}
+	for _, q := range qdiscs {
+		if q.Parent == tc.HandleRoot {
+			roots[q.Ifindex] = q
+		}
+	}
+	return roots, nil
+}
+
+// interfaceProto returns the Interface protobuf describing the link with the
+// specified attributes. root is the qdisc installed at the root of the link,
+// and may be nil.
+func interfaceProto(attrs *netlink.LinkAttrs, root *qdisc) *apb.Interface {
+	i := &apb.Interface{
+		Name:       attrs.Name,
+		Index:      uint32(attrs.Index),
+		Mac:        attrs.HardwareAddr.String(),
+		Mtu:        uint32(attrs.MTU),
+		AdminState: apb.AdminState_AS_DOWN,
+		OperState:  operState(attrs.OperState),
+	}
+	if attrs.Flags&net.FlagUp != 0 {
+		i.AdminState = apb.AdminState_AS_UP
+	}
+	if root != nil {
+		i.RootQdisc = &apb.Qdisc{
+			Kind:   root.Kind,
+			Handle: handleString(root.Handle),
+		}
+	}
+	return i
+}
+
+// stateParams returns the InterfaceStateParams describing the link with the specified
+// attributes. egress and ingress are the qdiscs installed on the link and on its ifb
+// device respectively, keyed by the handle of their parent. Where either impairs the
+// link, the impairments applied to packets that do not match a flow are decoded into
+// the returned parameters. st and ingressSt are the state that Aite programmed for the
+// interface and its ifb device respectively that cannot be read back from the kernel.
+func stateParams(attrs *netlink.LinkAttrs, egress, ingress map[uint32]*qdisc, st, ingressSt ifState) *apb.InterfaceStateParams {
+	p := &apb.InterfaceStateParams{
+		State: apb.InterfaceState_IS_ADMIN_DOWN,
+	}
+	if attrs.Flags&net.FlagUp != 0 {
+		p.State = apb.InterfaceState_IS_UP
+		switch {
+		case st.operDown, attrs.OperState == netlink.OperDown, attrs.OperState == netlink.OperLowerLayerDown:
+			p.State = apb.InterfaceState_IS_OPER_DOWN
+		}
+	}
+
+	// The same impairments are applied in each direction, and hence where both
+	// directions are impaired, they are decoded from the egress qdisc.
+	q, dist := defaultNetem(egress), st.delayDist
+	ingressQ := defaultNetem(ingress)
+	switch {
+	case q != nil && ingressQ != nil:
+		p.Direction = apb.Direction_DIR_BOTH
+	case q != nil:
+		p.Direction = apb.Direction_DIR_EGRESS
+	case ingressQ != nil:
+		p.Direction = apb.Direction_DIR_INGRESS
+		q, dist = ingressQ, ingressSt.delayDist
+	default:
+		return p
+	}
+
+	decodeNetem(p, q, dist)
+	// Loss that is emulating the interface being operationally down is not
+	// reported.
+	if st.operDown {
+		p.LossPct, p.LossPpm = 0, 0
+	}
+	return p
+}
+
+// defaultNetem returns the netem qdisc within tree that impairs packets that do not
+// match any flow, or nil if there is none. tree contains the qdiscs installed on a
+// device, keyed by the handle of their parent.
+func defaultNetem(tree map[uint32]*qdisc) *qdisc {
+	root := tree[tc.HandleRoot]
+	if isImpairment(root) && root.Kind == "prio" {
+		root = tree[bandClass(0)]
+	}
+	if root == nil || root.Kind != "netem" || root.Netem == nil {
+		return nil
+	}
+	return root
+}
+
+// decodeNetem decodes the impairments applied by the netem qdisc q into p. dist is
+// the delay distribution that Aite programmed into q, since the kernel does not
+// return distribution tables. It is not an error for q to be nil, in which case no
+// impairments are decoded.
+func decodeNetem(p *apb.InterfaceStateParams, q *qdisc, dist apb.DelayDistribution) {
+	if q == nil || q.Netem == nil {
+		return
+	}
+
+	// Latency and jitter are reported by the kernel in CPU ticks, which we
+	// convert back to µsec before rounding to the nearest msec.
+	p.LatencyMsec = msec(q.Netem.Qopt.Latency)
+	p.JitterMsec = msec(q.Netem.Qopt.Jitter)
+	// Loss is reported as a percentage where it is a whole number of percent,
+	// such that the returned parameters can be used to recreate the qdisc.
+	if pct := percentage(q.Netem.Qopt.Loss); probability(pct) == q.Netem.Qopt.Loss {
+		p.LossPct = pct
+	} else {
+		p.LossPpm = ppm(q.Netem.Qopt.Loss)
+	}
+	p.DuplicatePct = percentage(q.Netem.Qopt.Duplicate)
+	if c := q.Netem.Corr; c != nil {
+		p.LatencyCorrelationPct = percentage(c.Delay)
+		p.DuplicateCorrelationPct = percentage(c.Dup)
+	}
+	if c := q.Netem.Corrupt; c != nil {
+		p.CorruptPct = percentage(c.Probability)
+		p.CorruptCorrelationPct = percentage(c.Correlation)
+	}
+	if r := q.Netem.Reorder; r != nil {
+		p.ReorderPct = percentage(r.Probability)
+		p.ReorderCorrelationPct = percentage(r.Correlation)
+	}
+	p.DelayDistribution = dist
+	if r := q.Netem.Rate; r != nil {
+		rate := uint64(r.Rate)
+		if q.Netem.Rate64 != nil {
+			rate = *q.Netem.Rate64
+		}
+		p.RateBps = rate * 8
+		p.RatePacketOverhead = r.PacketOverhead
+		p.RateCellSize = uint32(r.CellSize)
+	}
+
+	switch m := q.Netem; {
+	case m.GE != nil:
+		p.LossModel = &apb.InterfaceStateParams_GilbertElliott{
+			GilbertElliott: &apb.GilbertElliottLossModel{
+				PPpm:        ppm(m.GE.P),
+				RPpm:        ppm(m.GE.R),
+				BadLossPpm:  ppm(m.GE.H),
+				GoodLossPpm: ppm(m.GE.K1),
+			},
+		}
+	case m.GI != nil:
+		p.LossModel = &apb.InterfaceStateParams_FourState{
+			FourState: &apb.FourStateLossModel{
+				P13Ppm: ppm(m.GI.P13),
+				P31Ppm: ppm(m.GI.P31),
+				P32Ppm: ppm(m.GI.P32),
+				P14Ppm: ppm(m.GI.P14),
+				P23Ppm: ppm(m.GI.P23),
+			},
+		}
+	}
+}
+
+// msec returns the number of milliseconds represented by the specified number of
+// CPU ticks, rounded to the nearest millisecond.
+func msec(ticks uint32) uint32 {
+	return uint32(math.Round(float64(core.Tick2Time(ticks)) / 1000.0))
+}
+
+// ppm returns the rate in parts per million represented by the netem probability p,
+// rounded to the nearest part per million.
+func ppm(p uint32) uint32 {
+	return uint32(math.Round(float64(p) / float64(MaxUint32) * 1e6))
+}
+
+// percentage returns the percentage represented by the netem probability p, which
+// is expressed as a proportion of MaxUint32, rounded to the nearest percent.
+func percentage(p uint32) uint32 {
+	return uint32(math.Round(float64(p) / float64(MaxUint32) * 100.0))
+}
+
+// operState maps the operational state reported by netlink to its protobuf
+// representation.
+func operState(s netlink.LinkOperState) apb.OperState {
+	switch s {
+	case netlink.OperUp:
+		return apb.OperState_OS_UP
+	case netlink.OperDown:
+		return apb.OperState_OS_DOWN
+	case netlink.OperLowerLayerDown:
+		return apb.OperState_OS_LOWER_LAYER_DOWN
+	case netlink.OperTesting:
+		return apb.OperState_OS_TESTING
+	case netlink.OperDormant:
+		return apb.OperState_OS_DORMANT
+	case netlink.OperNotPresent:
+		return apb.OperState_OS_NOT_PRESENT
+	default:
+		return apb.OperState_OS_UNSPECIFIED
+	}
+}
+
+// handleString returns the tc major:minor form of the handle h.
+func handleString(h uint32) string {
+	maj, min := core.SplitHandle(h)
+	return fmt.Sprintf("%x:%x", maj, min)
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// lease is held by a client for as long as it sends heartbeats on a KeepAlive
+// stream. Changes made to interfaces under the lease are rolled back when it ends.
+type lease struct {
+	// id is the ID of the lease, as specified by clients in requests.
+	id string
+	// snaps stores the state of each interface changed under the lease prior
+	// to the lease's first change to it, keyed by interface name.
+	snaps map[string]*snapshot
+}
+
+// KeepAlive implements the KeepAlive RPC for the Aite service. It holds a lease for
+// as long as the client sends heartbeats within the requested timeout, and rolls
+// back the changes made under the lease once the stream ends.
+func (s *S) KeepAlive(stream apb.Aite_KeepAliveServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.TimeoutMsec == 0 {
+		return status.Errorf(codes.InvalidArgument, "timeout must be specified")
+	}
+	timeout := time.Duration(req.TimeoutMsec) * time.Millisecond
+
+	l, err := s.newLease()
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot create lease, %v", err)
+	}
+	defer func() {
+		if err := s.releaseLease(l.id); err != nil {
+			klog.Errorf("cannot roll back lease %s, %v", l.id, err)
+		}
+	}()
+	klog.Infof("established lease %s with timeout %s", l.id, timeout)
+
+	if err := stream.Send(&apb.KeepAliveResponse{LeaseId: l.id}); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	heartbeats := make(chan *apb.KeepAliveRequest)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case heartbeats <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case err := <-errCh:
+			if errors.Is(err, io.EOF) {
+				klog.Infof("lease %s closed by client", l.id)
+				return nil
+			}
+			return err
+		case <-timer.C:
+			klog.Infof("lease %s expired after %s without heartbeat", l.id, timeout)
+			return status.Errorf(codes.DeadlineExceeded, "no heartbeat received within %s", timeout)
+		case req := <-heartbeats:
+			if req.TimeoutMsec != 0 {
+				timeout = time.Duration(req.TimeoutMsec) * time.Millisecond
+			}
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+			if err := stream.Send(&apb.KeepAliveResponse{LeaseId: l.id}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// newLease creates a new lease with a random ID.
+func (s *S) newLease() (*lease, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	l := &lease{
+		id:    hex.EncodeToString(b),
+		snaps: map[string]*snapshot{},
+	}
+
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	s.leases[l.id] = l
+	return l, nil
+}
+
+// releaseLease ends the lease with the specified ID, restoring each interface that
+// was changed under it. It is not an error for the lease to have already been released.
+func (s *S) releaseLease(id string) error {
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	return s.releaseLeaseLocked(id)
+}
+
+// releaseLeaseLocked implements releaseLease. It must be called with changeMu held.
+func (s *S) releaseLeaseLocked(id string) error {
+	l, ok := s.leases[id]
+	if !ok {
+		return nil
+	}
+	delete(s.leases, id)
+
+	var errs []error
+	for name, snap := range l.snaps {
+		// Any pending revert would return the interface to a state that was
+		// itself made under the lease, and hence is superseded.
+		s.cancelRevert(name)
+		delete(s.owners, name)
+		klog.Infof("rolling back device %s for lease %s", name, id)
+		if err := s.restore(snap); err != nil {
+			errs = append(errs, fmt.Errorf("cannot roll back device %s, %v", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// leaseFor returns the lease with the specified ID under which the interface with
+// the specified name is to be changed, or nil if the ID is empty, indicating that
+// the change is not made under a lease. It returns an error if the lease is not
+// active, or if the interface is held by another lease. It must be called with
+// changeMu held.
+func (s *S) leaseFor(id, name string) (*lease, error) {
+	if id == "" {
+		return nil, nil
+	}
+	l, ok := s.leases[id]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "lease %s is not active", id)
+	}
+	if owner := s.owners[name]; owner != nil && owner != l {
+		return nil, status.Errorf(codes.FailedPrecondition, "interface %s is held by another lease", name)
+	}
+	return l, nil
+}
+
+// acquireInterface records that the interface with the specified name is being
+// changed under the lease with the specified ID, taking a snapshot of the interface
+// if the lease has not previously changed it. An empty ID indicates that the change
+// is not made under a lease, in which case the interface is released from any lease
+// that held it, since the newer change supersedes it. It must be called with
+// changeMu held.
+func (s *S) acquireInterface(id, name string) error {
+	l, err := s.leaseFor(id, name)
+	if err != nil {
+		return err
+	}
+
+	if s.owners[name] != l {
+		s.releaseInterface(name)
+	}
+
+	if l == nil {
+		return nil
+	}
+	if _, ok := l.snaps[name]; !ok {
+		snap, err := s.snapshot(name)
+		if err != nil {
+			return status.Errorf(codes.Internal, "cannot record state of interface %s, %v", name, err)
+		}
+		l.snaps[name] = snap
+		s.owners[name] = l
+	}
+	return nil
+}
+
+// releaseInterface removes the interface with the specified name from any lease
+// under which it was changed. It must be called with changeMu held.
+func (s *S) releaseInterface(name string) {
+	if owner := s.owners[name]; owner != nil {
+		delete(owner.snaps, name)
+		delete(s.owners, name)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/florianl/go-tc"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// activeLeases returns the number of active leases held by s.
+func activeLeases(s *S) int {
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	return len(s.leases)
+}
+
+func TestKeepAlive(t *testing.T) {
+	impaired := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN, LatencyMsec: 50, Direction: apb.Direction_DIR_BOTH}
+
+	tests := []struct {
+		desc string
+		// timeout is the timeout with which the lease is established.
+		timeout time.Duration
+		// end ends the lease, given the stream that holds it and the function
+		// that cancels the stream's context.
+		end func(t *testing.T, stream apb.Aite_KeepAliveClient, cancel func())
+		// wantCode is the code with which the stream is expected to end.
+		wantCode codes.Code
+	}{{
+		desc:    "stream closed",
+		timeout: time.Hour,
+		end: func(t *testing.T, stream apb.Aite_KeepAliveClient, _ func()) {
+			if err := stream.CloseSend(); err != nil {
+				t.Fatalf("cannot close stream, %v", err)
+			}
+		},
+		wantCode: codes.OK,
+	}, {
+		desc:    "stream cancelled",
+		timeout: time.Hour,
+		end: func(t *testing.T, _ apb.Aite_KeepAliveClient, cancel func()) {
+			cancel()
+		},
+		wantCode: codes.Canceled,
+	}, {
+		desc:     "lease expired",
+		timeout:  50 * time.Millisecond,
+		end:      func(*testing.T, apb.Aite_KeepAliveClient, func()) {},
+		wantCode: codes.DeadlineExceeded,
+	}, {
+		desc:    "heartbeat shortens timeout",
+		timeout: time.Hour,
+		end: func(t *testing.T, stream apb.Aite_KeepAliveClient, _ func()) {
+			if err := stream.Send(&apb.KeepAliveRequest{TimeoutMsec: 50}); err != nil {
+				t.Fatalf("cannot send heartbeat, %v", err)
+			}
+			if _, err := stream.Recv(); err != nil {
+				t.Fatalf("did not receive response to heartbeat, %v", err)
+			}
+		},
+		wantCode: codes.DeadlineExceeded,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, b := newFakeServer(t)
+			c := newFakeClient(t, s)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream, err := c.KeepAlive(ctx)
+			if err != nil {
+				t.Fatalf("KeepAlive(): cannot open stream, %v", err)
+			}
+			if err := stream.Send(&apb.KeepAliveRequest{TimeoutMsec: uint32(tt.timeout.Milliseconds())}); err != nil {
+				t.Fatalf("KeepAlive(): cannot send request, %v", err)
+			}
+			resp, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("KeepAlive(): cannot establish lease, %v", err)
+			}
+
+			for _, name := range []string{"eth0", "eth1"} {
+				mustSet(t, s, &apb.SetInterfaceRequest{Name: name, Params: impaired, LeaseId: resp.GetLeaseId()})
+			}
+
+			tt.end(t, stream, cancel)
+			for err == nil {
+				_, err = stream.Recv()
+			}
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("KeepAlive(): did not get expected error code, got: %s (%v), want: %s", got, err, tt.wantCode)
+			}
+
+			waitFor(t, "lease to be released", func() bool { return activeLeases(s) == 0 })
+			for _, name := range []string{"eth0", "eth1"} {
+				wantParams(t, s, name, &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+				if root := b.Qdiscs(name)[tc.HandleRoot]; root != nil {
+					t.Errorf("KeepAlive(): impairment of %s not rolled back, got: %v", name, root)
+				}
+			}
+			if _, err := s.SetInterface(context.Background(), &apb.SetInterfaceRequest{Name: "eth1", Params: impaired, LeaseId: resp.GetLeaseId()}); status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("SetInterface(): did not get expected error for ended lease, got: %v, want code: %s", err, codes.FailedPrecondition)
+			}
+		})
+	}
+}
+
+func TestKeepAliveHeartbeats(t *testing.T) {
+	s, _ := newFakeServer(t)
+	c := newFakeClient(t, s)
+	stream, err := c.KeepAlive(context.Background())
+	if err != nil {
+		t.Fatalf("KeepAlive(): cannot open stream, %v", err)
+	}
+
+	if err := stream.Send(&apb.KeepAliveRequest{}); err != nil {
+		t.Fatalf("KeepAlive(): cannot send request, %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("KeepAlive(): did not get expected error for request without timeout, got: %v, want code: %s", err, codes.InvalidArgument)
+	}
+
+	stream, err = c.KeepAlive(context.Background())
+	if err != nil {
+		t.Fatalf("KeepAlive(): cannot open stream, %v", err)
+	}
+	if err := stream.Send(&apb.KeepAliveRequest{TimeoutMsec: 100}); err != nil {
+		t.Fatalf("KeepAlive(): cannot send request, %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("KeepAlive(): cannot establish lease, %v", err)
+	}
+
+	// Heartbeats sent within the timeout hold the lease for several multiples
+	// of it.
+	for i := 0; i < 10; i++ {
+		time.Sleep(20 * time.Millisecond)
+		if err := stream.Send(&apb.KeepAliveRequest{}); err != nil {
+			t.Fatalf("KeepAlive(): cannot send heartbeat, %v", err)
+		}
+		hb, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("KeepAlive(): lease not held by heartbeat %d, %v", i, err)
+		}
+		if hb.GetLeaseId() != resp.GetLeaseId() {
+			t.Errorf("KeepAlive(): did not get expected lease ID, got: %s, want: %s", hb.GetLeaseId(), resp.GetLeaseId())
+		}
+	}
+	if n := activeLeases(s); n != 1 {
+		t.Errorf("KeepAlive(): did not get expected active leases, got: %d, want: 1", n)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("KeepAlive(): cannot close stream, %v", err)
+	}
+}
+
+func TestLeaseOwnership(t *testing.T) {
+	s, _ := newFakeServer(t)
+	a, err := s.newLease()
+	if err != nil {
+		t.Fatalf("cannot create lease, %v", err)
+	}
+	b, err := s.newLease()
+	if err != nil {
+		t.Fatalf("cannot create lease, %v", err)
+	}
+	impaired := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50, Direction: apb.Direction_DIR_EGRESS}
+	changed := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 100, Direction: apb.Direction_DIR_EGRESS}
+
+	owner := func() *lease {
+		s.changeMu.Lock()
+		defer s.changeMu.Unlock()
+		return s.owners["eth1"]
+	}
+
+	mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: impaired, LeaseId: a.id})
+	if got := owner(); got != a {
+		t.Fatalf("SetInterface(): eth1 not held by lease, got: %v", got)
+	}
+
+	// A change under another lease is rejected, whether made alone or within a
+	// batch.
+	if _, err := s.SetInterface(context.Background(), &apb.SetInterfaceRequest{Name: "eth1", Params: changed, LeaseId: b.id}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("SetInterface(): did not get expected error for interface held by another lease, got: %v, want code: %s", err, codes.FailedPrecondition)
+	}
+	if _, err := s.BatchSetInterfaces(context.Background(), &apb.BatchSetInterfacesRequest{Requests: []*apb.SetInterfaceRequest{
+		{Name: "eth0", Params: changed, LeaseId: b.id},
+		{Name: "eth1", Params: changed, LeaseId: b.id},
+	}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("BatchSetInterfaces(): did not get expected error for interface held by another lease, got: %v, want code: %s", err, codes.FailedPrecondition)
+	}
+	wantParams(t, s, "eth0", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+	wantParams(t, s, "eth1", impaired)
+	if got := owner(); got != a {
+		t.Errorf("eth1 not held by lease after rejected changes, got: %v", got)
+	}
+
+	// A change without a lease supersedes the lease, after which the interface
+	// can be changed under another lease.
+	mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: changed})
+	if got := owner(); got != nil {
+		t.Errorf("SetInterface(): eth1 not released by change without lease, got owner: %s", got.id)
+	}
+	mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: impaired, LeaseId: b.id})
+	if got := owner(); got != b {
+		t.Errorf("SetInterface(): eth1 not held by second lease, got: %v", got)
+	}
+
+	// Ending the first lease does not restore the interface, which it no longer
+	// holds, whereas ending the second restores the state that it recorded.
+	if err := s.releaseLease(a.id); err != nil {
+		t.Fatalf("cannot release lease, %v", err)
+	}
+	wantParams(t, s, "eth1", impaired)
+	if err := s.releaseLease(b.id); err != nil {
+		t.Fatalf("cannot release lease, %v", err)
+	}
+	wantParams(t, s, "eth1", changed)
+}
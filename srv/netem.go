@@ -0,0 +1,272 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/florianl/go-tc"
+	"github.com/vishvananda/netlink/nl"
+)
+
+const (
+	// sizeofNetemQopt is the size of struct tc_netem_qopt, which precedes the
+	// netem attributes within TCA_OPTIONS.
+	sizeofNetemQopt = 24
+
+	// netemLossGI and netemLossGE are the attributes nested within
+	// TCA_NETEM_LOSS that specify the 4-state and Gilbert-Elliott loss models
+	// respectively, NETEM_LOSS_GI and NETEM_LOSS_GE in
+	// include/uapi/linux/pkt_sched.h.
+	netemLossGI = 1
+	netemLossGE = 2
+)
+
+// geModel is the Gilbert-Elliott loss model, struct tc_netem_gemodel from
+// include/uapi/linux/pkt_sched.h.
+type geModel struct {
+	P  uint32
+	R  uint32
+	H  uint32
+	K1 uint32
+}
+
+// giModel is the 4-state Markov loss model, struct tc_netem_gimodel from
+// include/uapi/linux/pkt_sched.h.
+type giModel struct {
+	P13 uint32
+	P31 uint32
+	P32 uint32
+	P14 uint32
+	P23 uint32
+}
+
+// netem is the configuration of a netem qdisc. It extends the tc package's
+// representation with the loss models that the tc package does not support.
+// At most one of GE and GI should be set.
+type netem struct {
+	tc.Netem
+	// GE is the Gilbert-Elliott loss model applied by the qdisc.
+	GE *geModel
+	// GI is the 4-state loss model applied by the qdisc.
+	GI *giModel
+}
+
+// marshal returns the encoding of n as the TCA_OPTIONS attribute of a netem qdisc.
+func (n *netem) marshal() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, nl.NativeEndian(), n.Qopt); err != nil {
+		return nil, fmt.Errorf("cannot encode netem options, %v", err)
+	}
+
+	attrs := []*nl.RtAttr{}
+	addStruct := func(attrType int, v any) error {
+		b := &bytes.Buffer{}
+		if err := binary.Write(b, nl.NativeEndian(), v); err != nil {
+			return fmt.Errorf("cannot encode netem attribute %d, %v", attrType, err)
+		}
+		attrs = append(attrs, nl.NewRtAttr(attrType, b.Bytes()))
+		return nil
+	}
+
+	if n.Corr != nil {
+		if err := addStruct(nl.TCA_NETEM_CORR, n.Corr); err != nil {
+			return nil, err
+		}
+	}
+	if n.DelayDist != nil {
+		if err := addStruct(nl.TCA_NETEM_DELAY_DIST, *n.DelayDist); err != nil {
+			return nil, err
+		}
+	}
+	if n.Reorder != nil {
+		if err := addStruct(nl.TCA_NETEM_REORDER, n.Reorder); err != nil {
+			return nil, err
+		}
+	}
+	if n.Corrupt != nil {
+		if err := addStruct(nl.TCA_NETEM_CORRUPT, n.Corrupt); err != nil {
+			return nil, err
+		}
+	}
+	if n.Rate != nil {
+		if err := addStruct(nl.TCA_NETEM_RATE, n.Rate); err != nil {
+			return nil, err
+		}
+	}
+	if n.Rate64 != nil {
+		attrs = append(attrs, nl.NewRtAttr(nl.TCA_NETEM_RATE64, nl.Uint64Attr(*n.Rate64)))
+	}
+
+	var model any
+	var modelType int
+	switch {
+	case n.GE != nil && n.GI != nil:
+		return nil, fmt.Errorf("cannot specify more than one loss model")
+	case n.GE != nil:
+		model, modelType = n.GE, netemLossGE
+	case n.GI != nil:
+		model, modelType = n.GI, netemLossGI
+	}
+	if model != nil {
+		b := &bytes.Buffer{}
+		if err := binary.Write(b, nl.NativeEndian(), model); err != nil {
+			return nil, fmt.Errorf("cannot encode netem loss model, %v", err)
+		}
+		loss := nl.NewRtAttr(nl.TCA_NETEM_LOSS, nil)
+		loss.AddRtAttr(modelType, b.Bytes())
+		attrs = append(attrs, loss)
+	}
+
+	for _, a := range attrs {
+		buf.Write(a.Serialize())
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalNetem decodes the TCA_OPTIONS attribute of a netem qdisc. Attributes
+// that Aite does not make use of are ignored.
+func unmarshalNetem(b []byte) (*netem, error) {
+	if len(b) < sizeofNetemQopt {
+		return nil, fmt.Errorf("netem options too short, length %d", len(b))
+	}
+
+	n := &netem{}
+	if err := binary.Read(bytes.NewReader(b[:sizeofNetemQopt]), nl.NativeEndian(), &n.Qopt); err != nil {
+		return nil, fmt.Errorf("cannot decode netem options, %v", err)
+	}
+
+	attrs, err := nl.ParseRouteAttr(b[sizeofNetemQopt:])
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse netem attributes, %v", err)
+	}
+
+	for _, a := range attrs {
+		var v any
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case nl.TCA_NETEM_CORR:
+			n.Corr = &tc.NetemCorr{}
+			v = n.Corr
+		case nl.TCA_NETEM_REORDER:
+			n.Reorder = &tc.NetemReorder{}
+			v = n.Reorder
+		case nl.TCA_NETEM_CORRUPT:
+			n.Corrupt = &tc.NetemCorrupt{}
+			v = n.Corrupt
+		case nl.TCA_NETEM_RATE:
+			n.Rate = &tc.NetemRate{}
+			v = n.Rate
+		case nl.TCA_NETEM_RATE64:
+			if len(a.Value) != 8 {
+				return nil, fmt.Errorf("invalid netem 64-bit rate, length %d", len(a.Value))
+			}
+			r := nl.NativeEndian().Uint64(a.Value)
+			n.Rate64 = &r
+			continue
+		case nl.TCA_NETEM_LOSS:
+			if err := n.unmarshalLoss(a.Value); err != nil {
+				return nil, err
+			}
+			continue
+		default:
+			continue
+		}
+		if err := binary.Read(bytes.NewReader(a.Value), nl.NativeEndian(), v); err != nil {
+			return nil, fmt.Errorf("cannot decode netem attribute %d, %v", a.Attr.Type, err)
+		}
+	}
+	return n, nil
+}
+
+// unmarshalLoss decodes the loss model within the TCA_NETEM_LOSS attribute b into n.
+func (n *netem) unmarshalLoss(b []byte) error {
+	attrs, err := nl.ParseRouteAttr(b)
+	if err != nil {
+		return fmt.Errorf("cannot parse netem loss model, %v", err)
+	}
+
+	for _, a := range attrs {
+		var v any
+		switch a.Attr.Type & nl.NLA_TYPE_MASK {
+		case netemLossGE:
+			n.GE = &geModel{}
+			v = n.GE
+		case netemLossGI:
+			n.GI = &giModel{}
+			v = n.GI
+		default:
+			continue
+		}
+		if err := binary.Read(bytes.NewReader(a.Value), nl.NativeEndian(), v); err != nil {
+			return fmt.Errorf("cannot decode netem loss model, %v", err)
+		}
+	}
+	return nil
+}
+
+// netemKey is the comparable form of the impairments applied by a netem qdisc.
+type netemKey struct {
+	qopt    tc.NetemQopt
+	corr    tc.NetemCorr
+	reorder tc.NetemReorder
+	corrupt tc.NetemCorrupt
+	rate    tc.NetemRate
+	rate64  uint64
+	ge      geModel
+	gi      giModel
+	hasGE   bool
+	hasGI   bool
+}
+
+// key returns the comparable form of n. The kernel reports attributes that were
+// not specified with zero values, and hence an absent attribute is represented as
+// its zero value. The distribution table is omitted, since the kernel does not
+// report it.
+func (n *netem) key() netemKey {
+	k := netemKey{qopt: n.Qopt}
+	if n.Corr != nil {
+		k.corr = *n.Corr
+	}
+	if n.Reorder != nil {
+		k.reorder = *n.Reorder
+	}
+	if n.Corrupt != nil {
+		k.corrupt = *n.Corrupt
+	}
+	if n.Rate != nil {
+		k.rate = *n.Rate
+	}
+	if n.Rate64 != nil {
+		k.rate64 = *n.Rate64
+	}
+	if n.GE != nil {
+		k.ge, k.hasGE = *n.GE, true
+	}
+	if n.GI != nil {
+		k.gi, k.hasGI = *n.GI, true
+	}
+	return k
+}
+
+// equal reports whether n and o apply the same impairments, disregarding their
+// distribution tables.
+func (n *netem) equal(o *netem) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	return n.key() == o.key()
+}
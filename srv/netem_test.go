@@ -0,0 +1,114 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/florianl/go-tc"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+func TestNetemRoundTrip(t *testing.T) {
+	rate64 := uint64(1 << 40)
+	qopt := tc.NetemQopt{Latency: 1000, Jitter: 100, Limit: 1000, Loss: 42, Duplicate: 7, Gap: 1}
+
+	tests := []struct {
+		desc string
+		in   *netem
+	}{{
+		desc: "options only",
+		in:   &netem{Netem: tc.Netem{Qopt: qopt}},
+	}, {
+		desc: "correlation, reordering and corruption",
+		in: &netem{Netem: tc.Netem{
+			Qopt:    qopt,
+			Corr:    &tc.NetemCorr{Delay: 1, Loss: 2, Dup: 3},
+			Reorder: &tc.NetemReorder{Probability: 4, Correlation: 5},
+			Corrupt: &tc.NetemCorrupt{Probability: 6, Correlation: 7},
+		}},
+	}, {
+		desc: "rate",
+		in: &netem{Netem: tc.Netem{
+			Qopt: qopt,
+			Rate: &tc.NetemRate{Rate: 125000, PacketOverhead: -4, CellSize: 64},
+		}},
+	}, {
+		desc: "64-bit rate",
+		in: &netem{Netem: tc.Netem{
+			Qopt:   qopt,
+			Rate:   &tc.NetemRate{Rate: MaxUint32},
+			Rate64: &rate64,
+		}},
+	}, {
+		desc: "Gilbert-Elliott loss model",
+		in: &netem{
+			Netem: tc.Netem{Qopt: qopt},
+			GE:    &geModel{P: 1, R: 2, H: 3, K1: 4},
+		},
+	}, {
+		desc: "4-state loss model",
+		in: &netem{
+			Netem: tc.Netem{Qopt: qopt},
+			GI:    &giModel{P13: 1, P31: 2, P32: 3, P14: 4, P23: 5},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.in.marshal()
+			if err != nil {
+				t.Fatalf("marshal(): cannot encode netem, %v", err)
+			}
+			got, err := unmarshalNetem(b)
+			if err != nil {
+				t.Fatalf("unmarshalNetem(): cannot decode netem, %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.in) {
+				t.Errorf("unmarshalNetem(): did not get expected netem, got: %+v, want: %+v", got, tt.in)
+			}
+		})
+	}
+}
+
+func TestNetemDelayDist(t *testing.T) {
+	// The distribution table is encoded, but is not decoded, since the kernel does
+	// not report it.
+	dist := distTables[apb.DelayDistribution_DD_NORMAL]
+	in := &netem{Netem: tc.Netem{Qopt: tc.NetemQopt{Limit: 1000}, DelayDist: &dist}}
+	b, err := in.marshal()
+	if err != nil {
+		t.Fatalf("marshal(): cannot encode netem, %v", err)
+	}
+	got, err := unmarshalNetem(b)
+	if err != nil {
+		t.Fatalf("unmarshalNetem(): cannot decode netem, %v", err)
+	}
+	if got.DelayDist != nil || got.Qopt != in.Qopt {
+		t.Errorf("unmarshalNetem(): did not get expected netem, got: %+v, want options: %+v without distribution", got, in.Qopt)
+	}
+}
+
+func TestNetemMarshalErrors(t *testing.T) {
+	in := &netem{GE: &geModel{}, GI: &giModel{}}
+	if _, err := in.marshal(); err == nil {
+		t.Errorf("marshal(): did not get error for multiple loss models")
+	}
+	if _, err := unmarshalNetem([]byte{0x1, 0x2}); err == nil {
+		t.Errorf("unmarshalNetem(): did not get error for truncated options")
+	}
+}
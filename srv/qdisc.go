@@ -0,0 +1,61 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"fmt"
+
+	"github.com/openconfig/aite/srv/backend"
+)
+
+// qdisc is a queueing discipline attached to an interface, as reported by the
+// backend, along with the decoded form of its options where Aite interprets them.
+type qdisc struct {
+	backend.Qdisc
+	// Netem is the decoded form of Options for netem qdiscs, and nil otherwise.
+	Netem *netem
+}
+
+// newQdisc returns the qdisc describing q, decoding its options if it is a netem
+// qdisc.
+func newQdisc(q *backend.Qdisc) (*qdisc, error) {
+	r := &qdisc{Qdisc: *q}
+	if q.Kind == "netem" && len(q.Options) != 0 {
+		n, err := unmarshalNetem(q.Options)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse netem qdisc on interface index %d, %v", q.Ifindex, err)
+		}
+		r.Netem = n
+	}
+	return r, nil
+}
+
+// dumpQdiscs returns all qdiscs that are attached to interfaces in the network namespace.
+func (s *S) dumpQdiscs() ([]*qdisc, error) {
+	raw, err := s.backend.QdiscList()
+	if err != nil {
+		return nil, err
+	}
+
+	qdiscs := []*qdisc{}
+	for _, r := range raw {
+		q, err := newQdisc(r)
+		if err != nil {
+			return nil, err
+		}
+		qdiscs = append(qdiscs, q)
+	}
+	return qdiscs, nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"k8s.io/klog"
+
+	"github.com/florianl/go-tc"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// snapshot is the state of an interface at a point in time, such that it can
+// later be restored.
+type snapshot struct {
+	// name is the name of the interface.
+	name string
+	// adminUp indicates that the interface was administratively up.
+	adminUp bool
+	// egress is the qdiscs that were installed on the interface, keyed by the
+	// handle of their parent.
+	egress map[uint32]*qdisc
+	// st is the state that Aite had programmed for the interface, including
+	// whether Aite had removed its carrier.
+	st ifState
+	// ingress is the qdiscs that were installed on the interface's ifb device,
+	// keyed by the handle of their parent, nil if its ingress traffic was not
+	// redirected.
+	ingress map[uint32]*qdisc
+	// ingressSt is the state that Aite had programmed for the ifb device.
+	ingressSt ifState
+}
+
+// revert is a pending restoration of an interface to a snapshot.
+type revert struct {
+	// snap is the state to which the interface is restored.
+	snap *snapshot
+	// timer fires when the interface is to be restored.
+	timer *time.Timer
+}
+
+// snapshot returns the current state of the interface with the specified name.
+func (s *S) snapshot(name string) (*snapshot, error) {
+	attrs, egress, ingress, err := s.linkState(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	st := s.programmed[name]
+	ingressSt := s.programmed[ifbName(attrs.Index)]
+	s.mu.Unlock()
+
+	return &snapshot{
+		name:      name,
+		adminUp:   attrs.Flags&net.FlagUp != 0,
+		egress:    egress,
+		st:        st,
+		ingress:   ingress,
+		ingressSt: ingressSt,
+	}, nil
+}
+
+// restore returns the interface described by snap to the state that it was in
+// when the snapshot was taken.
+func (s *S) restore(snap *snapshot) error {
+	if err := s.backend.LinkSetState(snap.name, snap.adminUp); err != nil {
+		return fmt.Errorf("cannot set interface state, %v", err)
+	}
+
+	intID, err := s.backend.LinkByName(snap.name)
+	if err != nil {
+		return fmt.Errorf("cannot find interface %s", snap.name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The carrier is only changed where Aite has removed or restored it since the
+	// snapshot was taken, such that a carrier that is down for another reason,
+	// such as the peer being down, is left as it is.
+	if s.programmed[snap.name].carrierDown != snap.st.carrierDown {
+		if err := s.backend.LinkSetCarrier(intID.Index, !snap.st.carrierDown); err != nil && !errors.Is(err, unix.EOPNOTSUPP) {
+			return fmt.Errorf("cannot set interface carrier, %v", err)
+		}
+	}
+
+	if err := s.restoreDevice(snap.name, intID.Index, snap.egress, snap.st); err != nil {
+		return err
+	}
+
+	if defaultNetem(snap.ingress) != nil {
+		ifb, err := s.ensureIngress(intID.Index)
+		if err != nil {
+			return fmt.Errorf("cannot redirect ingress traffic of interface, %v", err)
+		}
+		if err := s.restoreDevice(ifb.Name, ifb.Index, snap.ingress, snap.ingressSt); err != nil {
+			return err
+		}
+	} else if err := s.removeIngress(intID.Index); err != nil {
+		return fmt.Errorf("cannot remove ingress impairment from interface, %v", err)
+	}
+	return nil
+}
+
+// restoreDevice reinstalls the qdiscs in tree, keyed by the handle of their parent,
+// on the device with the specified name and index, where st is the state that Aite
+// had programmed for the device when they were recorded. It must be called with mu
+// held.
+func (s *S) restoreDevice(name string, index int, tree map[uint32]*qdisc, st ifState) error {
+	roots, err := s.rootQdiscs()
+	if err != nil {
+		return fmt.Errorf("cannot list qdiscs, %v", err)
+	}
+
+	// The current impairment is removed, rather than replaced, such that no
+	// distribution table is retained from it.
+	if root := roots[uint32(index)]; isImpairment(root) {
+		if err := s.backend.QdiscDel(root.Msg); err != nil {
+			return fmt.Errorf("cannot remove impairment from %s, %v", name, err)
+		}
+	}
+
+	root := tree[tc.HandleRoot]
+	switch {
+	case len(st.flows) != 0 && defaultNetem(tree) != nil:
+		opts, err := qdiscOptions(defaultNetem(tree), st.delayDist)
+		if err != nil {
+			return err
+		}
+		flows := []*flowQdisc{}
+		for i, f := range st.flows {
+			q := tree[bandClass(i+1)]
+			if q == nil {
+				return fmt.Errorf("cannot find qdisc impairing flow %d on %s", i, name)
+			}
+			o, err := qdiscOptions(q, f.GetParams().GetDelayDistribution())
+			if err != nil {
+				return err
+			}
+			flows = append(flows, &flowQdisc{flow: f, opts: o})
+		}
+		if err := s.replaceFlows(name, index, opts, st.delayDist, flows); err != nil {
+			return fmt.Errorf("cannot restore flow impairments on %s, %v", name, err)
+		}
+	case root != nil && root.Handle != 0:
+		// Qdiscs without a handle are the defaults attached by the kernel, which
+		// are reinstated when the impairment is removed.
+		opts, err := qdiscOptions(root, st.delayDist)
+		if err != nil {
+			return err
+		}
+		if err := s.backend.QdiscReplace(root.Msg, root.Kind, opts); err != nil {
+			return fmt.Errorf("cannot restore qdisc on %s, %v", name, err)
+		}
+	}
+
+	s.programmed[name] = st
+	return nil
+}
+
+// qdiscOptions returns the encoded options with which q can be recreated. The kernel
+// does not return the distribution table of a netem qdisc, and hence it is reinstated
+// from the delay distribution dist that Aite programmed.
+func qdiscOptions(q *qdisc, dist apb.DelayDistribution) ([]byte, error) {
+	if q.Netem == nil {
+		return q.Options, nil
+	}
+	n := *q.Netem
+	if table, ok := distTables[dist]; ok {
+		n.DelayDist = &table
+	}
+	opts, err := n.marshal()
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode netem qdisc, %v", err)
+	}
+	return opts, nil
+}
+
+// scheduleRevert restores the interface described by snap once the specified
+// duration has elapsed, unless the revert is cancelled beforehand. It must be
+// called with changeMu held.
+func (s *S) scheduleRevert(snap *snapshot, d time.Duration) {
+	r := &revert{snap: snap}
+	r.timer = time.AfterFunc(d, func() {
+		s.changeMu.Lock()
+		defer s.changeMu.Unlock()
+		// A newer request for the interface may have been received whilst the
+		// timer was firing, in which case the revert is no longer required.
+		if s.reverts[snap.name] != r {
+			return
+		}
+		delete(s.reverts, snap.name)
+
+		klog.Infof("reverting device %s after %s", snap.name, d)
+		if err := s.restore(snap); err != nil {
+			klog.Errorf("cannot revert device %s, %v", snap.name, err)
+		}
+	})
+	s.reverts[snap.name] = r
+}
+
+// cancelRevert cancels any pending revert of the interface with the specified
+// name. It must be called with changeMu held.
+func (s *S) cancelRevert(name string) {
+	if r, ok := s.reverts[name]; ok {
+		klog.Infof("cancelling pending revert of device %s", name)
+		r.timer.Stop()
+		delete(s.reverts, name)
+	}
+}
+
+// revertAll immediately restores all interfaces with pending reverts. It must be
+// called with changeMu held.
+func (s *S) revertAll() error {
+	var errs []error
+	for name, r := range s.reverts {
+		r.timer.Stop()
+		delete(s.reverts, name)
+		klog.Infof("reverting device %s", name)
+		if err := s.restore(r.snap); err != nil {
+			errs = append(errs, fmt.Errorf("cannot revert device %s, %v", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
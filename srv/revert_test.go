@@ -0,0 +1,240 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/florianl/go-tc"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// waitFor polls cond until it returns true, failing the test if it does not do so
+// within a few seconds.
+func waitFor(t *testing.T, desc string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", desc)
+		}
+	}
+}
+
+// pendingReverts returns the number of pending reverts held by s.
+func pendingReverts(s *S) int {
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	return len(s.reverts)
+}
+
+// wantParams checks that the params of the interface with the specified name are
+// want.
+func wantParams(t *testing.T, s *S, name string, want *apb.InterfaceStateParams) {
+	t.Helper()
+	if got := mustGet(t, s, name).GetParams(); !proto.Equal(got, want) {
+		t.Errorf("GetInterface(%s): did not get expected params, got: %s, want: %s", name, prototext.Format(got), prototext.Format(want))
+	}
+}
+
+func TestRevert(t *testing.T) {
+	up := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP}
+	latency := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10, Direction: apb.Direction_DIR_EGRESS}
+
+	tests := []struct {
+		desc string
+		name string
+		// initial and initialFlows are the params and flows with which the
+		// interface is configured before the change that is reverted, nil if
+		// it is not configured.
+		initial      *apb.InterfaceStateParams
+		initialFlows []*apb.FlowImpairment
+		// carrierDown indicates that the carrier of the interface is removed
+		// outside of Aite before the change.
+		carrierDown bool
+		in          *apb.InterfaceStateParams
+		want        *apb.InterfaceStateParams
+	}{{
+		desc: "impairment removed",
+		name: "eth1",
+		in:   &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50, LossPct: 2},
+		want: up,
+	}, {
+		desc:    "previous impairment restored",
+		name:    "eth1",
+		initial: latency,
+		in:      &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50, LossPct: 2, Direction: apb.Direction_DIR_BOTH},
+		want:    latency,
+	}, {
+		desc:    "flows restored",
+		name:    "eth1",
+		initial: latency,
+		initialFlows: []*apb.FlowImpairment{{
+			Match:  &apb.FlowMatch{IpProtocol: 17},
+			Params: &apb.InterfaceStateParams{LatencyMsec: 100},
+		}},
+		in:   &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP},
+		want: latency,
+	}, {
+		desc: "admin down",
+		name: "eth1",
+		in:   &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN},
+		want: up,
+	}, {
+		desc: "carrier removed",
+		name: "eth1",
+		in:   &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN},
+		want: up,
+	}, {
+		desc: "carrier emulated",
+		name: "eth0",
+		in:   &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN},
+		want: up,
+	}, {
+		// The carrier was not removed by Aite, and hence is not removed again
+		// when the interface is reverted.
+		desc:        "carrier removed outside of Aite",
+		name:        "eth1",
+		carrierDown: true,
+		in:          &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50},
+		want:        up,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, b := newFakeServer(t)
+			if tt.initial != nil {
+				mustSet(t, s, &apb.SetInterfaceRequest{Name: tt.name, Params: tt.initial, Flows: tt.initialFlows})
+			}
+			if tt.carrierDown {
+				attrs, err := b.LinkByName(tt.name)
+				if err != nil {
+					t.Fatalf("cannot find %s, %v", tt.name, err)
+				}
+				if err := b.LinkSetCarrier(attrs.Index, false); err != nil {
+					t.Fatalf("cannot remove carrier of %s, %v", tt.name, err)
+				}
+			}
+			before := b.Qdiscs(tt.name)
+
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: tt.name, Params: tt.in, DurationMsec: 20})
+			waitFor(t, "interface to be reverted", func() bool { return pendingReverts(s) == 0 })
+
+			wantParams(t, s, tt.name, tt.want)
+			after := b.Qdiscs(tt.name)
+			if len(after) != len(before) {
+				t.Errorf("did not get expected qdiscs after revert, got: %v, want: %v", after, before)
+			}
+			for parent, q := range before {
+				if got := after[parent]; got == nil || got.Kind != q.Kind || got.Handle != q.Handle {
+					t.Errorf("did not get expected qdisc with parent %s after revert, got: %v, want: %v", handleString(parent), got, q)
+				}
+			}
+			if got := mustGet(t, s, tt.name).GetFlows(); len(got) != len(tt.initialFlows) {
+				t.Errorf("GetInterface(): did not get expected flows after revert, got: %d flows, want: %d", len(got), len(tt.initialFlows))
+			}
+		})
+	}
+}
+
+func TestRevertCancelled(t *testing.T) {
+	impaired := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50, Direction: apb.Direction_DIR_EGRESS}
+
+	tests := []struct {
+		desc string
+		// newer makes the request that supersedes the change that is to be
+		// reverted.
+		newer func(t *testing.T, s *S)
+		want  *apb.InterfaceStateParams
+	}{{
+		desc: "newer SetInterface",
+		newer: func(t *testing.T, s *S) {
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: impaired})
+		},
+		want: impaired,
+	}, {
+		desc: "newer SetInterface with duration",
+		newer: func(t *testing.T, s *S) {
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: impaired, DurationMsec: 3600000})
+		},
+		want: impaired,
+	}, {
+		desc: "ClearInterface",
+		newer: func(t *testing.T, s *S) {
+			if _, err := s.ClearInterface(context.Background(), &apb.ClearInterfaceRequest{Name: "eth1"}); err != nil {
+				t.Fatalf("ClearInterface(): cannot clear interface, %v", err)
+			}
+		},
+		// Clearing the interface does not change its administrative state,
+		// which the revert would have restored.
+		want: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, _ := newFakeServer(t)
+			mustSet(t, s, &apb.SetInterfaceRequest{
+				Name:         "eth1",
+				Params:       &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN},
+				DurationMsec: 50,
+			})
+			tt.newer(t, s)
+
+			// The superseded revert would have fired by the time that the state
+			// is checked.
+			time.Sleep(150 * time.Millisecond)
+			wantParams(t, s, "eth1", tt.want)
+		})
+	}
+}
+
+func TestStopRevertsAll(t *testing.T) {
+	s, b := newFakeServer(t)
+	for _, name := range []string{"eth0", "eth1"} {
+		mustSet(t, s, &apb.SetInterfaceRequest{
+			Name:         name,
+			Params:       &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN, LatencyMsec: 50, Direction: apb.Direction_DIR_BOTH},
+			DurationMsec: 3600000,
+		})
+	}
+	if n := pendingReverts(s); n != 2 {
+		t.Fatalf("did not get expected pending reverts, got: %d, want: 2", n)
+	}
+
+	if err := s.Stop(); err != nil {
+		t.Fatalf("Stop(): cannot stop server, %v", err)
+	}
+	if n := pendingReverts(s); n != 0 {
+		t.Errorf("Stop(): pending reverts remain, got: %d", n)
+	}
+	for _, name := range []string{"eth0", "eth1"} {
+		wantParams(t, s, name, &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP})
+		if root := b.Qdiscs(name)[tc.HandleRoot]; root != nil {
+			t.Errorf("Stop(): impairment not removed from %s, got: %v", name, root)
+		}
+		attrs, err := b.LinkByName(name)
+		if err != nil {
+			t.Fatalf("cannot find %s, %v", name, err)
+		}
+		if _, err := b.LinkByName(ifbName(attrs.Index)); err == nil {
+			t.Errorf("Stop(): ifb device of %s not removed", name)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog"
+
+	"github.com/openconfig/magna/intf"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// RunScenario implements the RunScenario RPC for the Aite service. Each step is
+// validated before the scenario starts, and then executed at its offset from the
+// start of the scenario, such that delays in applying one step do not accumulate
+// into the timing of subsequent steps.
+func (s *S) RunScenario(req *apb.RunScenarioRequest, stream apb.Aite_RunScenarioServer) error {
+	if len(req.Steps) == 0 {
+		return status.Errorf(codes.InvalidArgument, "at least one step must be specified")
+	}
+
+	// Steps are copied such that the profile named by a SetInterface step can be
+	// resolved into its params.
+	steps := make([]*apb.ScenarioStep, len(req.Steps))
+	states := make([]intf.IntState, len(req.Steps))
+	for i, step := range req.Steps {
+		steps[i] = step
+		if i > 0 && step.OffsetMsec < req.Steps[i-1].OffsetMsec {
+			return status.Errorf(codes.InvalidArgument, "step %d is scheduled before step %d", i, i-1)
+		}
+		switch a := step.Action.(type) {
+		case *apb.ScenarioStep_SetInterface:
+			r, iState, err := s.validateSetRequest(a.SetInterface)
+			if err != nil {
+				return status.Errorf(status.Code(err), "invalid step %d, %s", i, status.Convert(err).Message())
+			}
+			steps[i] = &apb.ScenarioStep{
+				OffsetMsec: step.OffsetMsec,
+				Action:     &apb.ScenarioStep_SetInterface{SetInterface: r},
+			}
+			states[i] = iState
+		case *apb.ScenarioStep_ClearInterface:
+			if n := a.ClearInterface.GetName(); !s.validInterface(n) {
+				return status.Errorf(codes.InvalidArgument, "invalid step %d, invalid interface name specified, %s", i, n)
+			}
+		case *apb.ScenarioStep_ClearAll:
+		default:
+			return status.Errorf(codes.InvalidArgument, "invalid step %d, action must be specified", i)
+		}
+	}
+
+	sc := &scenario{restore: req.Restore, recorded: map[string]bool{}}
+	if req.Restore {
+		defer func() {
+			s.changeMu.Lock()
+			defer s.changeMu.Unlock()
+			if err := s.rollback(sc.snaps); err != nil {
+				klog.Errorf("cannot restore interfaces after scenario, %v", err)
+			}
+		}()
+	}
+
+	ctx := stream.Context()
+	start := time.Now()
+	klog.Infof("starting scenario of %d steps", len(req.Steps))
+	for i, step := range steps {
+		at := start.Add(time.Duration(step.OffsetMsec) * time.Millisecond)
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			klog.Infof("scenario cancelled before step %d", i)
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+		}
+
+		if err := s.runStep(ctx, sc, step, states[i]); err != nil {
+			return status.Errorf(status.Code(err), "cannot execute step %d, %s", i, status.Convert(err).Message())
+		}
+
+		if err := stream.Send(&apb.RunScenarioResponse{
+			Step:               uint32(i),
+			ScheduledTimestamp: at.UnixNano(),
+			Timestamp:          time.Now().UnixNano(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scenario is the state of a running scenario.
+type scenario struct {
+	// restore indicates that the interfaces changed by the scenario are to be
+	// restored once it ends.
+	restore bool
+	// recorded indicates the interfaces whose state has been recorded, keyed by
+	// interface name.
+	recorded map[string]bool
+	// snaps stores the state of each interface prior to the scenario's first
+	// change to it, in the order in which they were changed.
+	snaps []*snapshot
+}
+
+// record records the state of the interface with the specified name, if the
+// scenario is to restore it and has not previously changed it.
+func (sc *scenario) record(s *S, name string) error {
+	if !sc.restore || sc.recorded[name] {
+		return nil
+	}
+	snap, err := s.snapshot(name)
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot record state of interface %s, %v", name, err)
+	}
+	sc.recorded[name] = true
+	sc.snaps = append(sc.snaps, snap)
+	return nil
+}
+
+// runStep executes the step of the scenario sc, where iState is the administrative
+// state into which a SetInterface step places the interface.
+func (s *S) runStep(ctx context.Context, sc *scenario, step *apb.ScenarioStep, iState intf.IntState) error {
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+
+	var names []string
+	switch a := step.Action.(type) {
+	case *apb.ScenarioStep_SetInterface:
+		if err := sc.record(s, a.SetInterface.Name); err != nil {
+			return err
+		}
+		_, err := s.setInterface(ctx, a.SetInterface, iState)
+		return err
+	case *apb.ScenarioStep_ClearInterface:
+		names = []string{a.ClearInterface.Name}
+	case *apb.ScenarioStep_ClearAll:
+		names = s.changedInterfaces()
+	}
+
+	for _, name := range names {
+		if err := sc.record(s, name); err != nil {
+			return err
+		}
+		s.cancelRevert(name)
+		s.releaseInterface(name)
+		if err := s.clearInterface(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changedInterfaces returns the names of the interfaces that Aite has changed,
+// excluding the ifb devices that it created, in lexical order.
+func (s *S) changedInterfaces() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := map[string]bool{}
+	for name := range s.programmed {
+		seen[name] = true
+	}
+	for name := range s.original {
+		seen[name] = true
+	}
+
+	names := []string{}
+	for name := range seen {
+		if !strings.HasPrefix(name, ifbPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
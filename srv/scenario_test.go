@@ -0,0 +1,299 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// setStep returns a scenario step that sets the interface with the specified name
+// to params at offset msec.
+func setStep(offset uint64, name string, params *apb.InterfaceStateParams) *apb.ScenarioStep {
+	return &apb.ScenarioStep{
+		OffsetMsec: offset,
+		Action:     &apb.ScenarioStep_SetInterface{SetInterface: &apb.SetInterfaceRequest{Name: name, Params: params}},
+	}
+}
+
+// clearStep returns a scenario step that clears the interface with the specified
+// name at offset msec.
+func clearStep(offset uint64, name string) *apb.ScenarioStep {
+	return &apb.ScenarioStep{
+		OffsetMsec: offset,
+		Action:     &apb.ScenarioStep_ClearInterface{ClearInterface: &apb.ClearInterfaceRequest{Name: name}},
+	}
+}
+
+// clearAllStep returns a scenario step that clears all interfaces at offset msec.
+func clearAllStep(offset uint64) *apb.ScenarioStep {
+	return &apb.ScenarioStep{
+		OffsetMsec: offset,
+		Action:     &apb.ScenarioStep_ClearAll{ClearAll: &apb.ClearAllInterfaces{}},
+	}
+}
+
+func TestRunScenario(t *testing.T) {
+	up := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP}
+	adminDown := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}
+	latency := func(msec uint32) *apb.InterfaceStateParams {
+		return &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: msec, Direction: apb.Direction_DIR_EGRESS}
+	}
+
+	tests := []struct {
+		desc string
+		// initial are the changes made to interfaces before the scenario.
+		initial []*apb.SetInterfaceRequest
+		in      *apb.RunScenarioRequest
+		// want is the expected state of each interface once the scenario
+		// completes, keyed by interface name.
+		want map[string]*apb.InterfaceStateParams
+	}{{
+		desc: "steps applied in order",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			setStep(0, "eth1", latency(10)),
+			setStep(20, "eth1", latency(20)),
+			setStep(20, "eth1", latency(30)),
+			setStep(40, "eth0", adminDown),
+		}},
+		want: map[string]*apb.InterfaceStateParams{
+			"eth0": adminDown,
+			"eth1": latency(30),
+		},
+	}, {
+		desc: "interface cleared",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			setStep(0, "eth0", latency(10)),
+			setStep(0, "eth1", latency(10)),
+			clearStep(10, "eth1"),
+		}},
+		want: map[string]*apb.InterfaceStateParams{
+			"eth0": latency(10),
+			"eth1": up,
+		},
+	}, {
+		desc: "all interfaces cleared",
+		initial: []*apb.SetInterfaceRequest{
+			{Name: "eth0", Params: latency(50)},
+		},
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			setStep(0, "eth1", latency(10)),
+			clearAllStep(10),
+		}},
+		want: map[string]*apb.InterfaceStateParams{
+			"eth0": up,
+			"eth1": up,
+		},
+	}, {
+		desc: "interfaces restored",
+		initial: []*apb.SetInterfaceRequest{
+			{Name: "eth1", Params: latency(10)},
+		},
+		in: &apb.RunScenarioRequest{
+			Steps: []*apb.ScenarioStep{
+				setStep(0, "eth1", latency(50)),
+				setStep(10, "eth0", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN}),
+				setStep(20, "eth1", adminDown),
+			},
+			Restore: true,
+		},
+		want: map[string]*apb.InterfaceStateParams{
+			"eth0": up,
+			"eth1": latency(10),
+		},
+	}, {
+		desc: "interfaces cleared by scenario restored",
+		initial: []*apb.SetInterfaceRequest{
+			{Name: "eth0", Params: latency(50)},
+			{Name: "eth1", Params: latency(10)},
+		},
+		in: &apb.RunScenarioRequest{
+			Steps:   []*apb.ScenarioStep{clearAllStep(0)},
+			Restore: true,
+		},
+		want: map[string]*apb.InterfaceStateParams{
+			"eth0": latency(50),
+			"eth1": latency(10),
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, _ := newFakeServer(t)
+			for _, r := range tt.initial {
+				mustSet(t, s, r)
+			}
+
+			stream, err := newFakeClient(t, s).RunScenario(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("RunScenario(): cannot start scenario, %v", err)
+			}
+			var got []*apb.RunScenarioResponse
+			for {
+				resp, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("RunScenario(): did not complete scenario, %v", err)
+				}
+				got = append(got, resp)
+			}
+
+			if len(got) != len(tt.in.Steps) {
+				t.Fatalf("RunScenario(): did not get expected progress messages, got: %d, want: %d", len(got), len(tt.in.Steps))
+			}
+			for i, resp := range got {
+				if resp.GetStep() != uint32(i) {
+					t.Errorf("RunScenario(): did not get expected step, got: %d, want: %d", resp.GetStep(), i)
+				}
+				if resp.GetTimestamp() < resp.GetScheduledTimestamp() {
+					t.Errorf("RunScenario(): step %d applied before it was scheduled, got: %d, scheduled: %d", i, resp.GetTimestamp(), resp.GetScheduledTimestamp())
+				}
+				// Each step is scheduled at its offset from the first.
+				offset := time.Duration(tt.in.Steps[i].OffsetMsec-tt.in.Steps[0].OffsetMsec) * time.Millisecond
+				if d := time.Duration(resp.GetScheduledTimestamp() - got[0].GetScheduledTimestamp()); d != offset {
+					t.Errorf("RunScenario(): step %d not scheduled at its offset, got: %s, want: %s", i, d, offset)
+				}
+			}
+			for name, want := range tt.want {
+				wantParams(t, s, name, want)
+			}
+		})
+	}
+}
+
+func TestRunScenarioCancelled(t *testing.T) {
+	impaired := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50, Direction: apb.Direction_DIR_EGRESS}
+	up := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP}
+
+	tests := []struct {
+		desc    string
+		restore bool
+		// wantEth1 is the expected state of eth1, which the scenario changes
+		// before it is cancelled.
+		wantEth1 *apb.InterfaceStateParams
+	}{{
+		desc:     "changes retained",
+		wantEth1: impaired,
+	}, {
+		desc:     "changes restored",
+		restore:  true,
+		wantEth1: up,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, _ := newFakeServer(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// The second step is scheduled after the test completes, such that
+			// the scenario is cancelled before it.
+			stream, err := newFakeClient(t, s).RunScenario(ctx, &apb.RunScenarioRequest{
+				Steps: []*apb.ScenarioStep{
+					setStep(0, "eth1", impaired),
+					setStep(3600000, "eth0", impaired),
+				},
+				Restore: tt.restore,
+			})
+			if err != nil {
+				t.Fatalf("RunScenario(): cannot start scenario, %v", err)
+			}
+			if _, err := stream.Recv(); err != nil {
+				t.Fatalf("RunScenario(): did not get progress of first step, %v", err)
+			}
+			cancel()
+			if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+				t.Fatalf("RunScenario(): did not get expected error, got: %v, want code: %s", err, codes.Canceled)
+			}
+
+			// The scenario is restored once the server observes the cancellation,
+			// which may be after the client does.
+			waitFor(t, "scenario to end", func() bool {
+				return proto.Equal(mustGet(t, s, "eth1").GetParams(), tt.wantEth1)
+			})
+			wantParams(t, s, "eth0", up)
+		})
+	}
+}
+
+func TestRunScenarioErrors(t *testing.T) {
+	impaired := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50}
+
+	tests := []struct {
+		desc     string
+		in       *apb.RunScenarioRequest
+		wantCode codes.Code
+	}{{
+		desc:     "no steps",
+		in:       &apb.RunScenarioRequest{},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "steps out of order",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			setStep(20, "eth1", impaired),
+			setStep(10, "eth0", impaired),
+		}},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "invalid impairment",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			setStep(0, "eth0", impaired),
+			setStep(10, "eth1", &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LossPct: 101}),
+		}},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "unknown interface",
+		in: &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{
+			setStep(0, "eth0", impaired),
+			clearStep(10, "eth9"),
+		}},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "no action",
+		in:       &apb.RunScenarioRequest{Steps: []*apb.ScenarioStep{{OffsetMsec: 0}}},
+		wantCode: codes.InvalidArgument,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, b := newFakeServer(t)
+			stream, err := newFakeClient(t, s).RunScenario(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("RunScenario(): cannot start scenario, %v", err)
+			}
+			if _, err := stream.Recv(); status.Code(err) != tt.wantCode {
+				t.Fatalf("RunScenario(): did not get expected error code, got: %s (%v), want: %s", status.Code(err), err, tt.wantCode)
+			}
+			// Since the scenario is validated before it starts, none of its
+			// steps are applied.
+			for _, name := range []string{"eth0", "eth1"} {
+				if q := b.Qdiscs(name); len(q) != 0 {
+					t.Errorf("RunScenario(): %s changed by invalid scenario, got qdiscs: %v", name, q)
+				}
+			}
+		})
+	}
+}
@@ -18,62 +18,214 @@ package srv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net"
+	"sync"
 	"time"
 
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"k8s.io/klog"
 
 	"github.com/florianl/go-tc"
 	"github.com/florianl/go-tc/core"
 	"github.com/openconfig/magna/intf"
+	"github.com/vishvananda/netlink"
 
 	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv/backend"
 )
 
 // S is the wrapper for the Aite service implementation.
 type S struct {
-	tc *tc.Tc
+	// backend reads and programs the state of the interfaces.
+	backend backend.Backend
+
+	// mu protects original and programmed, and serialises changes to qdiscs.
+	mu sync.Mutex
+	// original stores the root qdisc of each interface before Aite first
+	// manipulated it, keyed by interface name. A nil value indicates that
+	// the interface had the default qdisc attached by the kernel. Only the
+	// root qdisc is stored, and not the classes, qdiscs or filters below it.
+	original map[string]*qdisc
+	// programmed stores the state that Aite has programmed for each interface
+	// that cannot be read back from the kernel, keyed by interface name.
+	programmed map[string]ifState
+
+	// changeMu serialises requests that change the state of interfaces, such
+	// that a pending revert or lease rollback cannot interleave with a newer
+	// request. It also protects reverts, leases, owners and flapping.
+	changeMu sync.Mutex
+	// reverts stores the pending revert of each interface, keyed by interface
+	// name.
+	reverts map[string]*revert
+	// leases stores the active leases, keyed by lease ID.
+	leases map[string]*lease
+	// owners stores the lease under which each interface was last changed,
+	// keyed by interface name.
+	owners map[string]*lease
+	// flapping indicates the interfaces that are being flapped, keyed by
+	// interface name.
+	flapping map[string]bool
+
+	// profiles stores the named profiles that can be applied to interfaces,
+	// keyed by name. It is not modified once the server has been created.
+	profiles map[string]*apb.InterfaceStateParams
 
 	*apb.UnimplementedAiteServer
 }
 
-// New returns a new Aite server.
-func New() (*S, error) {
-	tconn, err := tc.Open(&tc.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("cannot open Tc connection, %w", err)
+// ifState is the state of an interface that has been programmed by Aite but cannot
+// be read back from the kernel.
+type ifState struct {
+	// delayDist is the delay distribution programmed into the netem qdisc of
+	// the interface, since the kernel does not return distribution tables when
+	// qdiscs are retrieved.
+	delayDist apb.DelayDistribution
+	// operDown indicates that the interface does not support its carrier being
+	// set, and hence the netem qdisc of the interface is dropping all packets
+	// to emulate it being operationally down.
+	operDown bool
+	// carrierDown indicates that Aite removed the carrier of the interface,
+	// since the kernel does not report the carrier of interfaces that are
+	// administratively down.
+	carrierDown bool
+	// flows are the flows impaired on the interface, as requested, since the
+	// kernel's representation of the filters that match them is not decoded.
+	flows []*apb.FlowImpairment
+}
+
+// Option configures an Aite server.
+type Option func(*S) error
+
+// WithProfiles specifies the named profiles that can be applied to interfaces by
+// specifying their name in a SetInterfaceRequest, keyed by name. Each profile is
+// validated as per the params of a SetInterfaceRequest, with the exception that
+// the state of the interface need not be specified.
+func WithProfiles(profiles map[string]*apb.InterfaceStateParams) Option {
+	return func(s *S) error {
+		for name, p := range profiles {
+			if name == "" {
+				return fmt.Errorf("profile name must be specified")
+			}
+			if p == nil {
+				return fmt.Errorf("params must be specified for profile %s", name)
+			}
+			if err := validateImpairments(p); err != nil {
+				return fmt.Errorf("invalid profile %s, %s", name, status.Convert(err).Message())
+			}
+			s.profiles[name] = proto.Clone(p).(*apb.InterfaceStateParams)
+		}
+		return nil
 	}
+}
 
-	return &S{tc: tconn}, nil
+// WithBackend specifies the backend through which the server reads and programs
+// the state of interfaces, in place of the default Netlink backend. The backend
+// is closed when the server is stopped.
+func WithBackend(b backend.Backend) Option {
+	return func(s *S) error {
+		if b == nil {
+			return fmt.Errorf("backend must be specified")
+		}
+		s.backend = b
+		return nil
+	}
 }
 
-// Stop stops the Aite server, cleaning up internal state.
+// New returns a new Aite server, configured with the specified options. Unless
+// another backend is specified, interfaces are programmed via netlink.
+func New(opts ...Option) (*S, error) {
+	s := &S{
+		original:   map[string]*qdisc{},
+		programmed: map[string]ifState{},
+		reverts:    map[string]*revert{},
+		leases:     map[string]*lease{},
+		owners:     map[string]*lease{},
+		flapping:   map[string]bool{},
+		profiles:   map[string]*apb.InterfaceStateParams{},
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.backend == nil {
+		b, err := backend.NewNetlink()
+		if err != nil {
+			return nil, err
+		}
+		s.backend = b
+	}
+	return s, nil
+}
+
+// Stop stops the Aite server, cleaning up internal state. Interfaces with pending
+// reverts, or that were changed under an active lease, are restored immediately.
 func (s *S) Stop() error {
-	if err := s.tc.Close(); err != nil {
-		return fmt.Errorf("cannot close Tc connection, %v", err)
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	if err := s.revertAll(); err != nil {
+		return err
 	}
-	return nil
+	for id := range s.leases {
+		if err := s.releaseLeaseLocked(id); err != nil {
+			return err
+		}
+	}
+
+	return s.backend.Close()
 }
 
 // SetInterfaceState implements the InterfaceState RPC for the Aite service. It
 // manipulates parameters of the interface including impairments.
 func (s *S) SetInterface(ctx context.Context, req *apb.SetInterfaceRequest) (*apb.SetInterfaceResponse, error) {
-	if req.Name == "" || !intf.ValidInterface(req.Name) {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid interface name specified, %s", req.Name)
+	req, iState, err := s.validateSetRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.changeMu.Lock()
+	defer s.changeMu.Unlock()
+	return s.setInterface(ctx, req, iState)
+}
+
+// validateSetRequest checks that the SetInterfaceRequest req can be applied. It
+// returns the request that is to be applied, in which any profile named by req has
+// been resolved into its params, along with the administrative state that the
+// interface should be placed into.
+func (s *S) validateSetRequest(req *apb.SetInterfaceRequest) (*apb.SetInterfaceRequest, intf.IntState, error) {
+	if !s.validInterface(req.Name) {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "invalid interface name specified, %s", req.Name)
+	}
+
+	if req.ProfileName != "" {
+		profile, ok := s.profiles[req.ProfileName]
+		if !ok {
+			return nil, 0, status.Errorf(codes.NotFound, "unknown profile %s", req.ProfileName)
+		}
+		// The params are merged into the profile, such that a field that is set
+		// to its zero value in params does not override that of the profile.
+		params := proto.Clone(profile).(*apb.InterfaceStateParams)
+		if req.Params != nil {
+			proto.Merge(params, req.Params)
+		}
+		req = proto.Clone(req).(*apb.SetInterfaceRequest)
+		req.Params = params
 	}
 
 	if req.GetParams() == nil {
-		return nil, status.Errorf(codes.InvalidArgument, "params is a required argument")
+		return nil, 0, status.Errorf(codes.InvalidArgument, "params is a required argument")
 	}
 
 	params := req.GetParams()
 	if params.State == apb.InterfaceState_IS_UNSPECIFIED {
-		return nil, status.Errorf(codes.InvalidArgument, "interface state must be specified")
+		return nil, 0, status.Errorf(codes.InvalidArgument, "interface state must be specified")
 	}
 
 	var iState intf.IntState
@@ -82,60 +234,263 @@ func (s *S) SetInterface(ctx context.Context, req *apb.SetInterfaceRequest) (*ap
 		iState = intf.InterfaceUp
 	case apb.InterfaceState_IS_ADMIN_DOWN:
 		iState = intf.InterfaceDown
+	case apb.InterfaceState_IS_OPER_DOWN:
+		// The interface remains administratively up, with its carrier being
+		// removed by applyInterfaceState.
+		iState = intf.InterfaceUp
 	default:
-		return nil, status.Errorf(codes.InvalidArgument, "invalid interface state %s specified", params.State)
+		return nil, 0, status.Errorf(codes.InvalidArgument, "invalid interface state %s specified", params.State)
+	}
+
+	if err := ValidateImpairments(params, req.Flows); err != nil {
+		return nil, 0, err
+	}
+	return req, iState, nil
+}
+
+// ValidateImpairments checks that the impairments specified by params, and those of
+// each of flows, can be applied by Aite, such that requests can be checked before
+// they are sent, e.g., when validating a scenario. params may be nil where they are
+// not known, such as when they are specified by a profile, in which case only flows
+// are checked.
+func ValidateImpairments(params *apb.InterfaceStateParams, flows []*apb.FlowImpairment) error {
+	if params != nil {
+		if err := validateImpairments(params); err != nil {
+			return err
+		}
+	}
+
+	if len(flows) > maxFlows {
+		return status.Errorf(codes.InvalidArgument, "at most %d flows can be impaired, got: %d", maxFlows, len(flows))
+	}
+	for i, f := range flows {
+		if f.GetParams() == nil {
+			return status.Errorf(codes.InvalidArgument, "params must be specified for flow %d", i)
+		}
+		if err := validateMatch(f.GetMatch()); err != nil {
+			return err
+		}
+		if err := validateImpairments(f.GetParams()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setInterface applies the validated SetInterfaceRequest req, placing the interface
+// into the administrative state iState. It must be called with changeMu held.
+func (s *S) setInterface(ctx context.Context, req *apb.SetInterfaceRequest, iState intf.IntState) (*apb.SetInterfaceResponse, error) {
+	if err := s.checkNotFlapping(req.Name); err != nil {
+		return nil, err
 	}
+	if err := s.acquireInterface(req.LeaseId, req.Name); err != nil {
+		return nil, err
+	}
+	s.cancelRevert(req.Name)
 
-	if params.LossPct >= 100 {
-		return nil, status.Errorf(codes.InvalidArgument, "loss percentage must be 0 < loss <= 100, got: %d", params.LossPct)
+	var snap *snapshot
+	if req.DurationMsec != 0 {
+		var err error
+		if snap, err = s.snapshot(req.Name); err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot record state of interface %s, %v", req.Name, err)
+		}
 	}
 
-	if err := s.applyInterfaceState(ctx, req.Name, iState, params.LossPct, params.LatencyMsec); err != nil {
+	params := req.GetParams()
+	changed, err := s.applyInterfaceState(ctx, req.Name, iState, params, req.Flows)
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot set interface state, %v", err)
 	}
+	if !changed {
+		klog.Infof("interface %s is already in the requested state", req.Name)
+	}
+
+	if snap != nil {
+		s.scheduleRevert(snap, time.Duration(req.DurationMsec)*time.Millisecond)
+	}
 
 	return &apb.SetInterfaceResponse{
-		Name: req.Name,
-		Params: &apb.InterfaceStateParams{
-			State:       req.GetParams().GetState(),
-			LatencyMsec: req.GetParams().GetLatencyMsec(),
-			LossPct:     req.GetParams().GetLossPct(),
-		},
+		Name:    req.Name,
+		Params:  proto.Clone(params).(*apb.InterfaceStateParams),
+		Changed: changed,
 	}, nil
 }
 
-// applyInterfaceState applies the state changes to the interface with the specified name. The
-// state indicates any change in administrative or operational status. lossPct indicates a percentage
-// latency that should be applied, and latencyMsec is an additional latency that should be applied
-// to packets traversing the interface.
-func (s *S) applyInterfaceState(ctx context.Context, name string, state intf.IntState, lossPct, latencyMsec uint32) error {
-	if err := intf.InterfaceState(name, state); err != nil {
-		return status.Errorf(codes.Internal, "cannot set interface state, %v", err)
+// validateImpairments checks that the impairments specified in params can be applied.
+func validateImpairments(params *apb.InterfaceStateParams) error {
+	if params.LossPct > 100 {
+		return status.Errorf(codes.InvalidArgument, "loss percentage must be 0 < loss <= 100, got: %d", params.LossPct)
 	}
 
-	if err := s.impairInterface(ctx, name, lossPct, latencyMsec); err != nil {
-		return err
+	if params.LossPpm > 1000000 {
+		return status.Errorf(codes.InvalidArgument, "loss must be 0 <= loss <= 1000000 ppm, got: %d", params.LossPpm)
 	}
 
+	if params.RateBps != 0 && params.RateBps < 8 {
+		return status.Errorf(codes.InvalidArgument, "rate must be at least 8 bps, got: %d", params.RateBps)
+	}
+
+	if params.LossPct != 0 && params.LossPpm != 0 {
+		return status.Errorf(codes.InvalidArgument, "loss cannot be specified as both a percentage and ppm")
+	}
+
+	if params.GetLossModel() != nil && (params.LossPct != 0 || params.LossPpm != 0) {
+		return status.Errorf(codes.InvalidArgument, "loss model cannot be specified alongside loss percentage or ppm")
+	}
+
+	var model []uint32
+	switch {
+	case params.GetGilbertElliott() != nil:
+		m := params.GetGilbertElliott()
+		model = []uint32{m.PPpm, m.RPpm, m.BadLossPpm, m.GoodLossPpm}
+	case params.GetFourState() != nil:
+		m := params.GetFourState()
+		model = []uint32{m.P13Ppm, m.P31Ppm, m.P32Ppm, m.P14Ppm, m.P23Ppm}
+	}
+	for _, p := range model {
+		if p > 1000000 {
+			return status.Errorf(codes.InvalidArgument, "loss model probabilities must be 0 <= p <= 1000000 ppm, got: %d", p)
+		}
+	}
+
+	for _, p := range []struct {
+		name string
+		val  uint32
+	}{
+		{"latency correlation", params.LatencyCorrelationPct},
+		{"duplicate percentage", params.DuplicatePct},
+		{"duplicate correlation", params.DuplicateCorrelationPct},
+		{"corrupt percentage", params.CorruptPct},
+		{"corrupt correlation", params.CorruptCorrelationPct},
+		{"reorder percentage", params.ReorderPct},
+		{"reorder correlation", params.ReorderCorrelationPct},
+	} {
+		if p.val > 100 {
+			return status.Errorf(codes.InvalidArgument, "%s must be 0 <= value <= 100, got: %d", p.name, p.val)
+		}
+	}
+
+	if params.ReorderPct != 0 && params.LatencyMsec == 0 {
+		return status.Errorf(codes.InvalidArgument, "latency must be specified to reorder packets")
+	}
+
+	if params.DelayDistribution != apb.DelayDistribution_DD_UNIFORM {
+		if _, ok := distTables[params.DelayDistribution]; !ok {
+			return status.Errorf(codes.InvalidArgument, "invalid delay distribution %s specified", params.DelayDistribution)
+		}
+		if params.JitterMsec == 0 {
+			return status.Errorf(codes.InvalidArgument, "jitter must be specified with delay distribution %s", params.DelayDistribution)
+		}
+	}
 	return nil
 }
 
+// applyInterfaceState applies the state changes to the interface with the specified name. The
+// state indicates any change in administrative or operational status, and params specifies the
+// impairments that should be applied to packets traversing the interface, other than those of
+// the specified flows. The current state of the interface is read first, such that only the
+// parts of it that differ are programmed. It reports whether any change was made.
+func (s *S) applyInterfaceState(ctx context.Context, name string, state intf.IntState, params *apb.InterfaceStateParams, flows []*apb.FlowImpairment) (bool, error) {
+	attrs, err := s.backend.LinkByName(name)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "cannot find interface %s", name)
+	}
+
+	s.mu.Lock()
+	st := s.programmed[name]
+	s.mu.Unlock()
+
+	changed := false
+	if up := state == intf.InterfaceUp; up != (attrs.Flags&net.FlagUp != 0) {
+		if err := s.backend.LinkSetState(name, up); err != nil {
+			return false, status.Errorf(codes.Internal, "cannot set interface state, %v", err)
+		}
+		changed = true
+	}
+
+	operDown := params.State == apb.InterfaceState_IS_OPER_DOWN
+	carrierDown := st.carrierDown
+	fallback := false
+	switch {
+	case !changed && carrierMatches(attrs, st, operDown):
+		// An interface that does not support its carrier being removed is already
+		// dropping all packets.
+		fallback = operDown && st.operDown
+	default:
+		switch err := s.backend.LinkSetCarrier(attrs.Index, !operDown); {
+		case errors.Is(err, unix.EOPNOTSUPP):
+			fallback = operDown
+		case err != nil:
+			return false, status.Errorf(codes.Internal, "cannot set interface carrier, %v", err)
+		default:
+			carrierDown = operDown
+			changed = true
+		}
+	}
+
+	// Where the carrier cannot be removed, the interface drops all packets that it
+	// sends and receives, regardless of the requested direction, such that it
+	// appears to be operationally down to both itself and its peer.
+	if fallback {
+		klog.Infof("device %s does not support carrier changes, dropping all packets", name)
+		impair := proto.Clone(params).(*apb.InterfaceStateParams)
+		impair.LossPct, impair.LossPpm, impair.LossModel = 0, 1000000, nil
+		impair.Direction = apb.Direction_DIR_BOTH
+		params, flows = impair, nil
+	}
+
+	impaired, err := s.impairInterface(ctx, name, params, flows)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st = s.programmed[name]
+	st.operDown = fallback
+	st.carrierDown = carrierDown
+	s.programmed[name] = st
+
+	return changed || impaired, nil
+}
+
+// carrierMatches reports whether the carrier of the interface with attributes attrs,
+// for which Aite programmed st, is already in the requested state, where operDown
+// indicates that it should be removed. The kernel reports the carrier only for
+// interfaces that are administratively up, and hence for those that are down the
+// state that Aite programmed is used.
+func carrierMatches(attrs *netlink.LinkAttrs, st ifState, operDown bool) bool {
+	switch {
+	case operDown && st.operDown:
+		return true
+	case attrs.Flags&net.FlagUp == 0:
+		return st.carrierDown == operDown
+	default:
+		return (attrs.RawFlags&unix.IFF_LOWER_UP == 0) == operDown && st.carrierDown == operDown
+	}
+}
+
 const (
 	// MaxUint32 is the maximum uint32 value. In netem a number of values are
 	// expressed by indicating the proportion of max uint32.
 	MaxUint32 uint32 = 0xFFFFFFFF
 )
 
-// impairInterface applies the specified impairments to the interface with the specified name. lossPct
-// indicates a percentage packet loss to be applied, and latencyMsec an additional artificial latency
-// to be applied. The function will set the underlying kernel parameters regardless of their current
-// state.
-func (s *S) impairInterface(ctx context.Context, name string, lossPct, latencyMsec uint32) error {
-	intID, err := net.InterfaceByName(name)
-	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "cannot find interface %s", name)
-	}
+// probability returns the netem representation of the percentage pct, expressed as
+// a proportion of MaxUint32.
+func probability(pct uint32) uint32 {
+	return uint32(math.Round(float64(MaxUint32) * (float64(pct) / 100.0)))
+}
+
+// ppmProbability returns the netem representation of the rate ppm, expressed in parts
+// per million, as a proportion of MaxUint32.
+func ppmProbability(ppm uint32) uint32 {
+	return uint32(math.Round(float64(MaxUint32) * (float64(ppm) / 1e6)))
+}
 
+// netemOptions returns the encoded options of a netem qdisc that applies the impairments
+// specified in params to packets traversing the interface with the specified name.
+func netemOptions(name string, params *apb.InterfaceStateParams) ([]byte, error) {
 	qopt := tc.NetemQopt{
 		// Maximum number of packets that should be in the buffer,
 		// the default value of 0 will stop packets flowing.
@@ -145,40 +500,429 @@ func (s *S) impairInterface(ctx context.Context, name string, lossPct, latencyMs
 	// latency is set in µsec, and is fed to the kernel as CPU
 	// ticks, not msec. Thus, we need to use the tc package's
 	// helper to convert to ticks.
-	qopt.Latency = core.Time2Tick(latencyMsec * 1000)
-	klog.Infof("setting device %s latency to %d msec", name, latencyMsec)
-
-	p := uint32(math.Round(float64(MaxUint32) * (float64(lossPct) / 100.0)))
-	qopt.Loss = p
-	klog.Infof("setting device %s loss to %d%% (val: %d)", name, lossPct, p)
-
-	qdisc := tc.Object{
-		Msg: tc.Msg{
-			Family:  unix.AF_UNSPEC,
-			Ifindex: uint32(intID.Index),
-			Handle:  core.BuildHandle(0x1, 0x0),
-			Parent:  tc.HandleRoot,
-			Info:    0,
-		},
-		Attribute: tc.Attribute{
-			Kind: "netem",
-			Netem: &tc.Netem{
-				Qopt: qopt,
+	qopt.Latency = core.Time2Tick(params.LatencyMsec * 1000)
+	klog.Infof("setting device %s latency to %d msec", name, params.LatencyMsec)
+
+	// jitter is expressed in the same units as latency.
+	qopt.Jitter = core.Time2Tick(params.JitterMsec * 1000)
+	klog.Infof("setting device %s jitter to %d msec (correlation: %d%%, distribution: %s)", name, params.JitterMsec, params.LatencyCorrelationPct, params.DelayDistribution)
+
+	if params.LossPpm != 0 {
+		p := ppmProbability(params.LossPpm)
+		qopt.Loss = p
+		klog.Infof("setting device %s loss to %d ppm (val: %d)", name, params.LossPpm, p)
+	} else {
+		p := probability(params.LossPct)
+		qopt.Loss = p
+		klog.Infof("setting device %s loss to %d%% (val: %d)", name, params.LossPct, p)
+	}
+
+	qopt.Duplicate = probability(params.DuplicatePct)
+	klog.Infof("setting device %s duplication to %d%% (correlation: %d%%)", name, params.DuplicatePct, params.DuplicateCorrelationPct)
+
+	// Packets are only reordered when the gap is non-zero, a gap of one causes the
+	// reorder probability to be considered for every packet.
+	if params.ReorderPct != 0 {
+		qopt.Gap = 1
+	}
+	klog.Infof("setting device %s reordering to %d%% (correlation: %d%%)", name, params.ReorderPct, params.ReorderCorrelationPct)
+	klog.Infof("setting device %s corruption to %d%% (correlation: %d%%)", name, params.CorruptPct, params.CorruptCorrelationPct)
+
+	n := &netem{
+		Netem: tc.Netem{
+			Qopt: qopt,
+			// Correlations, reordering and corruption are always specified, since the
+			// kernel retains the existing values if they are omitted when changing the
+			// qdisc.
+			Corr: &tc.NetemCorr{
+				Delay: probability(params.LatencyCorrelationPct),
+				Dup:   probability(params.DuplicateCorrelationPct),
+			},
+			Reorder: &tc.NetemReorder{
+				Probability: probability(params.ReorderPct),
+				Correlation: probability(params.ReorderCorrelationPct),
+			},
+			Corrupt: &tc.NetemCorrupt{
+				Probability: probability(params.CorruptPct),
+				Correlation: probability(params.CorruptCorrelationPct),
+			},
+			Rate: &tc.NetemRate{
+				PacketOverhead: params.RatePacketOverhead,
+				CellSize:       int32(params.RateCellSize),
 			},
 		},
 	}
 
-	// We should not ever block on the qdisc call below, but to ensure that we have a
+	// netem's rate is expressed in bytes per second. Rates that cannot be expressed
+	// in 32 bits are specified in an additional 64-bit attribute, with the 32-bit
+	// rate set to its maximum value.
+	rate := params.RateBps / 8
+	n.Rate.Rate = uint32(rate)
+	if rate > uint64(MaxUint32) {
+		n.Rate.Rate = MaxUint32
+		n.Rate64 = &rate
+	}
+	klog.Infof("setting device %s rate to %d bps (packet overhead: %d, cell size: %d)", name, params.RateBps, params.RatePacketOverhead, params.RateCellSize)
+	if dist, ok := distTables[params.DelayDistribution]; ok {
+		n.DelayDist = &dist
+	}
+
+	switch {
+	case params.GetGilbertElliott() != nil:
+		m := params.GetGilbertElliott()
+		n.GE = &geModel{
+			P:  ppmProbability(m.PPpm),
+			R:  ppmProbability(m.RPpm),
+			H:  ppmProbability(m.BadLossPpm),
+			K1: ppmProbability(m.GoodLossPpm),
+		}
+		klog.Infof("setting device %s loss model to Gilbert-Elliott %v", name, m)
+	case params.GetFourState() != nil:
+		m := params.GetFourState()
+		n.GI = &giModel{
+			P13: ppmProbability(m.P13Ppm),
+			P31: ppmProbability(m.P31Ppm),
+			P32: ppmProbability(m.P32Ppm),
+			P14: ppmProbability(m.P14Ppm),
+			P23: ppmProbability(m.P23Ppm),
+		}
+		klog.Infof("setting device %s loss model to 4-state %v", name, m)
+	}
+
+	return n.marshal()
+}
+
+// impairInterface applies the impairments specified in params to the interface with the
+// specified name, in the direction specified by params, with the impairments of each of
+// flows applied to the packets that match it, removing impairments from the direction
+// that is not impaired. Devices whose qdiscs already apply the requested impairments are
+// not reprogrammed, such that their queues are retained. It reports whether any change
+// was made.
+func (s *S) impairInterface(ctx context.Context, name string, params *apb.InterfaceStateParams, flows []*apb.FlowImpairment) (bool, error) {
+	opts, err := netemOptions(name, params)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "cannot encode impairment for interface, %v", err)
+	}
+
+	flowQdiscs := []*flowQdisc{}
+	for i, f := range flows {
+		klog.Infof("setting device %s flow %d to match %v", name, i, f.GetMatch())
+		o, err := netemOptions(name, f.GetParams())
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "cannot encode impairment for flow %d, %v", i, err)
+		}
+		flowQdiscs = append(flowQdiscs, &flowQdisc{flow: f, opts: o})
+	}
+
+	// We should not ever block on the qdisc calls below, but to ensure that we have a
 	// reasonable belt and braces approach here, we use the parent context to ensure
 	// that we cancel the context if we do.
 	_, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	klog.Infof("calling qdisc replace")
-	if err := s.tc.Qdisc().Replace(&qdisc); err != nil {
-		return status.Errorf(codes.Internal, "cannot apply impairment to interface, %v", err)
+	// Delay distributions other than uniform have been validated to have a table.
+	dist := params.DelayDistribution
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	intID, egress, ingress, err := s.linkState(name)
+	switch {
+	case errors.Is(err, backend.ErrLinkNotFound):
+		return false, status.Errorf(codes.InvalidArgument, "cannot find interface %s", name)
+	case err != nil:
+		return false, status.Errorf(codes.Internal, "cannot read state of interface %s, %v", name, err)
+	}
+	ifb, err := s.backend.LinkByName(ifbName(intID.Index))
+	if err != nil && !errors.Is(err, backend.ErrLinkNotFound) {
+		return false, status.Errorf(codes.Internal, "cannot find ifb device %s, %v", ifbName(intID.Index), err)
+	}
+
+	// Where no impairment is requested, no qdisc is installed, such that states
+	// that do not impair traffic can be applied without netem.
+	impair := len(flows) != 0 || impairs(params)
+
+	changed := false
+	klog.Infof("setting device %s impairment direction to %s", name, params.Direction)
+	switch {
+	case impair && params.Direction != apb.Direction_DIR_INGRESS:
+		impaired, err := deviceImpaired(egress, s.programmed[name], opts, dist, flowQdiscs)
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "cannot compare impairment of interface, %v", err)
+		}
+		if impaired {
+			klog.Infof("device %s is already impaired as requested", name)
+			break
+		}
+		if err := s.saveOriginal(name, uint32(intID.Index)); err != nil {
+			return false, status.Errorf(codes.Internal, "cannot record original qdisc for interface %s, %v", name, err)
+		}
+		if err := s.impairDevice(name, intID.Index, opts, dist, flowQdiscs); err != nil {
+			return false, status.Errorf(codes.Internal, "cannot apply impairment to interface, %v", err)
+		}
+		changed = true
+	default:
+		changed = isImpairment(egress[tc.HandleRoot]) || s.original[name] != nil
+		if err := s.removeNetem(name, intID.Index); err != nil {
+			return false, status.Errorf(codes.Internal, "cannot remove egress impairment from interface, %v", err)
+		}
+	}
+
+	switch {
+	case impair && params.Direction != apb.Direction_DIR_EGRESS:
+		// The ingress traffic is only considered to be impaired if it is still
+		// redirected to an ifb device that is up.
+		if ifb != nil && ifb.Flags&net.FlagUp != 0 && egress[tc.HandleIngress] != nil {
+			impaired, err := deviceImpaired(ingress, s.programmed[ifb.Name], opts, dist, flowQdiscs)
+			if err != nil {
+				return false, status.Errorf(codes.Internal, "cannot compare ingress impairment of interface, %v", err)
+			}
+			if impaired {
+				klog.Infof("device %s is already impaired as requested", ifb.Name)
+				break
+			}
+		}
+		ifb, err := s.ensureIngress(intID.Index)
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "cannot redirect ingress traffic of interface, %v", err)
+		}
+		if err := s.impairDevice(ifb.Name, ifb.Index, opts, dist, flowQdiscs); err != nil {
+			return false, status.Errorf(codes.Internal, "cannot apply ingress impairment to interface, %v", err)
+		}
+		changed = true
+	default:
+		changed = changed || ifb != nil
+		if err := s.removeIngress(intID.Index); err != nil {
+			return false, status.Errorf(codes.Internal, "cannot remove ingress impairment from interface, %v", err)
+		}
+	}
+
+	return changed, nil
+}
+
+// impairs reports whether params specify an impairment of the packets to which they
+// are applied.
+func impairs(params *apb.InterfaceStateParams) bool {
+	return params.LatencyMsec != 0 || params.JitterMsec != 0 || params.LossPct != 0 || params.LossPpm != 0 ||
+		params.LossModel != nil || params.DuplicatePct != 0 || params.CorruptPct != 0 || params.ReorderPct != 0 ||
+		params.RateBps != 0
+}
+
+// deviceImpaired reports whether tree, the qdiscs of a device keyed by the handle of
+// their parent, and st, the state that Aite programmed for it, already apply the
+// impairments that impairDevice would install with the same arguments.
+func deviceImpaired(tree map[uint32]*qdisc, st ifState, opts []byte, dist apb.DelayDistribution, flows []*flowQdisc) (bool, error) {
+	if st.delayDist != dist || len(st.flows) != len(flows) {
+		return false, nil
+	}
+
+	root := tree[tc.HandleRoot]
+	if len(flows) == 0 {
+		want, err := unmarshalNetem(opts)
+		if err != nil {
+			return false, err
+		}
+		return root != nil && root.Kind == "netem" && root.Handle == core.BuildHandle(0x1, 0x0) && root.Netem.equal(want), nil
+	}
+
+	if root == nil || root.Kind != "prio" || root.Handle != flowRootHandle {
+		return false, nil
+	}
+	bandOpts := [][]byte{opts}
+	for i, f := range flows {
+		if !proto.Equal(st.flows[i], f.flow) {
+			return false, nil
+		}
+		bandOpts = append(bandOpts, f.opts)
+	}
+	for band, o := range bandOpts {
+		want, err := unmarshalNetem(o)
+		if err != nil {
+			return false, err
+		}
+		if q := tree[bandClass(band)]; q == nil || q.Kind != "netem" || !q.Netem.equal(want) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// impairDevice installs a netem qdisc with the encoded options opts at the root of the
+// device with the specified name and index, or where flows are specified, the qdiscs
+// that impair each flow, with opts applied to the remaining packets. It must be called
+// with mu held.
+func (s *S) impairDevice(name string, index int, opts []byte, dist apb.DelayDistribution, flows []*flowQdisc) error {
+	if len(flows) != 0 {
+		return s.replaceFlows(name, index, opts, dist, flows)
+	}
+	return s.replaceNetem(name, index, opts, dist)
+}
+
+// replaceNetem installs a netem qdisc with the encoded options at the root of the
+// device with the specified name and index, replacing any existing root qdisc. dist
+// is the delay distribution whose table is included in the options. It must be
+// called with mu held.
+func (s *S) replaceNetem(name string, index int, opts []byte, dist apb.DelayDistribution) error {
+	msg := tc.Msg{
+		Family:  unix.AF_UNSPEC,
+		Ifindex: uint32(index),
+		Handle:  core.BuildHandle(0x1, 0x0),
+		Parent:  tc.HandleRoot,
+		Info:    0,
+	}
+
+	// The kernel retains the distribution table of an existing netem qdisc when it
+	// is changed without one being specified, and provides no means to remove it.
+	// Thus, to return to uniformly distributed jitter, the qdisc is removed such
+	// that it is recreated without a table. Similarly, the prio qdisc used to
+	// impair flows is removed, since its kind cannot be changed.
+	st := s.programmed[name]
+	switch {
+	case st.delayDist != apb.DelayDistribution_DD_UNIFORM && dist == apb.DelayDistribution_DD_UNIFORM:
+		klog.Infof("removing netem qdisc with delay distribution from device %s", name)
+		if err := s.backend.QdiscDel(msg); err != nil {
+			return fmt.Errorf("cannot remove delay distribution, %v", err)
+		}
+	case len(st.flows) != 0:
+		klog.Infof("removing prio qdisc impairing flows from device %s", name)
+		if err := s.backend.QdiscDel(msg); err != nil {
+			return fmt.Errorf("cannot remove flow impairments, %v", err)
+		}
+	}
+
+	klog.Infof("calling qdisc replace for device %s", name)
+	if err := s.backend.QdiscReplace(msg, "netem", opts); err != nil {
+		return err
 	}
 	klog.Infof("returned from qdisc replace")
 
+	st.delayDist = dist
+	st.flows = nil
+	s.programmed[name] = st
+
+	return nil
+}
+
+// removeNetem removes any netem qdisc from the root of the interface with the
+// specified name and index, and reinstalls the root qdisc that was recorded by
+// saveOriginal, if any. Only the root qdisc is reinstalled, without any classes,
+// child qdiscs or filters that it had. It must be called with mu held.
+func (s *S) removeNetem(name string, index int) error {
+	roots, err := s.rootQdiscs()
+	if err != nil {
+		return fmt.Errorf("cannot list qdiscs, %v", err)
+	}
+
+	if root := roots[uint32(index)]; isImpairment(root) {
+		klog.Infof("removing %s qdisc from device %s", root.Kind, name)
+		if err := s.backend.QdiscDel(root.Msg); err != nil {
+			return err
+		}
+	}
+
+	if orig := s.original[name]; orig != nil {
+		klog.Infof("restoring %s qdisc on device %s", orig.Kind, name)
+		// The qdisc is recreated with the options that the kernel returned for it,
+		// such that it is identical to the original.
+		if err := s.backend.QdiscReplace(orig.Msg, orig.Kind, orig.Options); err != nil {
+			return fmt.Errorf("cannot restore original qdisc, %v", err)
+		}
+	}
+	delete(s.original, name)
+
+	st := s.programmed[name]
+	st.delayDist = apb.DelayDistribution_DD_UNIFORM
+	st.flows = nil
+	s.programmed[name] = st
+
+	return nil
+}
+
+// saveOriginal records the root qdisc of the interface with the specified name and
+// index, such that it can be restored when the interface is cleared. The qdisc is
+// only recorded the first time that Aite manipulates the interface. Since netem
+// replaces the root qdisc, any classes, child qdiscs and filters of a classful
+// root qdisc are removed by the kernel, and are neither recorded nor restored.
+// It must be called with s.mu held.
+func (s *S) saveOriginal(name string, index uint32) error {
+	if _, ok := s.original[name]; ok {
+		return nil
+	}
+
+	roots, err := s.rootQdiscs()
+	if err != nil {
+		return err
+	}
+
+	root := roots[index]
+	// Qdiscs with a zero handle are attached by the kernel by default, and are
+	// reattached automatically when the netem qdisc is removed. An impairment
+	// that we have no record of is assumed to have been installed by a previous
+	// instance of Aite.
+	if root != nil && (root.Handle == 0 || isImpairment(root)) {
+		root = nil
+	}
+	s.original[name] = root
+	return nil
+}
+
+// ClearInterface implements the ClearInterface RPC for the Aite service. It removes
+// any netem qdisc from the interface and restores the qdisc that the interface had
+// before Aite first manipulated it, along with its carrier if Aite removed it. The
+// hierarchy below a classful root qdisc is not restored.
+func (s *S) ClearInterface(ctx context.Context, req *apb.ClearInterfaceRequest) (*apb.ClearInterfaceResponse, error) {
+	if !s.validInterface(req.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid interface name specified, %s", req.Name)
+	}
+
+	s.changeMu.Lock()
+	s.cancelRevert(req.Name)
+	s.releaseInterface(req.Name)
+	err := s.clearInterface(req.Name)
+	s.changeMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, egress, _, err := s.linkState(req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get interface %s, %v", req.Name, err)
+	}
+
+	return &apb.ClearInterfaceResponse{
+		Interface: interfaceProto(attrs, egress[tc.HandleRoot]),
+	}, nil
+}
+
+// clearInterface removes the netem qdisc from the interface with the specified name,
+// and reinstalls the root qdisc that was recorded by saveOriginal, if any. Any
+// redirection of the interface's ingress traffic is also removed, and the carrier
+// of the interface is restored if Aite removed it.
+func (s *S) clearInterface(name string) error {
+	intID, err := s.backend.LinkByName(name)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot find interface %s", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.programmed[name].carrierDown {
+		klog.Infof("restoring carrier of device %s", name)
+		switch err := s.backend.LinkSetCarrier(intID.Index, true); {
+		case errors.Is(err, unix.EOPNOTSUPP):
+		case err != nil:
+			return status.Errorf(codes.Internal, "cannot restore carrier of interface, %v", err)
+		}
+	}
+
+	if err := s.removeNetem(name, intID.Index); err != nil {
+		return status.Errorf(codes.Internal, "cannot remove impairment from interface, %v", err)
+	}
+	if err := s.removeIngress(intID.Index); err != nil {
+		return status.Errorf(codes.Internal, "cannot remove ingress impairment from interface, %v", err)
+	}
+	delete(s.programmed, name)
+
 	return nil
 }
@@ -0,0 +1,787 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv/backend/fake"
+)
+
+// newFakeServer returns a server backed by a fake with the links eth0, which does
+// not support its carrier being set, and eth1, which does.
+func newFakeServer(t *testing.T) (*S, *fake.Backend) {
+	t.Helper()
+	b := fake.New()
+	b.AddLink("eth0", false)
+	b.AddLink("eth1", true)
+	s, err := New(WithBackend(b))
+	if err != nil {
+		t.Fatalf("cannot create server, %v", err)
+	}
+	return s, b
+}
+
+// newFakeClient returns a client of s, which is served over an in-memory connection
+// until the test completes, such that streaming RPCs can be called.
+func newFakeClient(t *testing.T, s *S) apb.AiteClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	apb.RegisterAiteServer(gs, s)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("cannot dial server, %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return apb.NewAiteClient(conn)
+}
+
+// mustSet applies req to s, failing the test if it cannot be applied.
+func mustSet(t *testing.T, s *S, req *apb.SetInterfaceRequest) *apb.SetInterfaceResponse {
+	t.Helper()
+	resp, err := s.SetInterface(context.Background(), req)
+	if err != nil {
+		t.Fatalf("SetInterface(%s): cannot set interface, %v", prototext.Format(req), err)
+	}
+	return resp
+}
+
+// mustGet returns the state of the interface with the specified name, failing the
+// test if it cannot be retrieved.
+func mustGet(t *testing.T, s *S, name string) *apb.GetInterfaceResponse {
+	t.Helper()
+	resp, err := s.GetInterface(context.Background(), &apb.GetInterfaceRequest{Name: name})
+	if err != nil {
+		t.Fatalf("GetInterface(%s): cannot get interface, %v", name, err)
+	}
+	return resp
+}
+
+func TestSetInterfaceValidation(t *testing.T) {
+	up := func(p *apb.InterfaceStateParams) *apb.InterfaceStateParams {
+		p.State = apb.InterfaceState_IS_UP
+		return p
+	}
+	flow := func(m *apb.FlowMatch) []*apb.FlowImpairment {
+		return []*apb.FlowImpairment{{Match: m, Params: &apb.InterfaceStateParams{LatencyMsec: 10}}}
+	}
+
+	tests := []struct {
+		desc     string
+		in       *apb.SetInterfaceRequest
+		wantCode codes.Code
+	}{{
+		desc:     "no name",
+		in:       &apb.SetInterfaceRequest{Params: up(&apb.InterfaceStateParams{})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "unknown interface",
+		in:       &apb.SetInterfaceRequest{Name: "eth9", Params: up(&apb.InterfaceStateParams{})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "no params",
+		in:       &apb.SetInterfaceRequest{Name: "eth1"},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "no state",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{LatencyMsec: 10}},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "unknown profile",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", ProfileName: "satellite"},
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "loss percentage above 100",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{LossPct: 101})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "loss percentage of 100",
+		in:   &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{LossPct: 100})},
+	}, {
+		desc:     "loss ppm above 1000000",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{LossPpm: 1000001})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "loss percentage and ppm",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{LossPct: 1, LossPpm: 100})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "loss model and percentage",
+		in: &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{
+			LossPct:   1,
+			LossModel: &apb.InterfaceStateParams_GilbertElliott{GilbertElliott: &apb.GilbertElliottLossModel{PPpm: 100}},
+		})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "loss model probability above 1000000",
+		in: &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{
+			LossModel: &apb.InterfaceStateParams_FourState{FourState: &apb.FourStateLossModel{P13Ppm: 1000001}},
+		})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "rate below 8 bps",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{RateBps: 4})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "percentage above 100",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{DuplicatePct: 101})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "delay distribution without jitter",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{LatencyMsec: 10, DelayDistribution: apb.DelayDistribution_DD_NORMAL})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "too many flows",
+		in: &apb.SetInterfaceRequest{
+			Name:   "eth1",
+			Params: up(&apb.InterfaceStateParams{}),
+			Flows:  make([]*apb.FlowImpairment, maxFlows+1),
+		},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "flow without params",
+		in: &apb.SetInterfaceRequest{
+			Name:   "eth1",
+			Params: up(&apb.InterfaceStateParams{}),
+			Flows:  []*apb.FlowImpairment{{Match: &apb.FlowMatch{IpProtocol: 17}}},
+		},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "flow with invalid prefix",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{}), Flows: flow(&apb.FlowMatch{SrcPrefix: "192.0.2.1"})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "flow with mixed address families",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{}), Flows: flow(&apb.FlowMatch{SrcPrefix: "192.0.2.0/24", DstPrefix: "2001:db8::/32"})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "flow with ports without protocol",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{}), Flows: flow(&apb.FlowMatch{DstPort: 80})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "flow with invalid VLAN",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", Params: up(&apb.InterfaceStateParams{}), Flows: flow(&apb.FlowMatch{VlanId: 4095})},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "flow with invalid impairment",
+		in: &apb.SetInterfaceRequest{
+			Name:   "eth1",
+			Params: up(&apb.InterfaceStateParams{}),
+			Flows:  []*apb.FlowImpairment{{Params: &apb.InterfaceStateParams{LossPpm: 1000001}}},
+		},
+		wantCode: codes.InvalidArgument,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, b := newFakeServer(t)
+			_, err := s.SetInterface(context.Background(), tt.in)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("SetInterface(): did not get expected error code, got: %s (%v), want: %s", got, err, tt.wantCode)
+			}
+			if err != nil && len(b.Qdiscs("eth1")) != 0 {
+				t.Errorf("SetInterface(): qdiscs installed for invalid request, got: %v", b.Qdiscs("eth1"))
+			}
+		})
+	}
+}
+
+func TestSetInterfaceParams(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *apb.InterfaceStateParams
+		// want is the params returned by GetInterface, which are those of in if
+		// unspecified.
+		want *apb.InterfaceStateParams
+	}{{
+		desc: "latency and jitter",
+		in:   &apb.InterfaceStateParams{LatencyMsec: 50, JitterMsec: 5, LatencyCorrelationPct: 25},
+	}, {
+		desc: "loss percentage",
+		in:   &apb.InterfaceStateParams{LossPct: 2},
+	}, {
+		desc: "loss of 100 percent",
+		in:   &apb.InterfaceStateParams{LossPct: 100},
+	}, {
+		desc: "loss ppm",
+		in:   &apb.InterfaceStateParams{LossPpm: 150},
+	}, {
+		desc: "loss ppm of a whole percentage",
+		in:   &apb.InterfaceStateParams{LossPpm: 20000},
+		want: &apb.InterfaceStateParams{LossPct: 2},
+	}, {
+		desc: "duplication, corruption and reordering",
+		in: &apb.InterfaceStateParams{
+			LatencyMsec:             10,
+			DuplicatePct:            3,
+			DuplicateCorrelationPct: 10,
+			CorruptPct:              4,
+			CorruptCorrelationPct:   20,
+			ReorderPct:              5,
+			ReorderCorrelationPct:   30,
+		},
+	}, {
+		desc: "rate",
+		in:   &apb.InterfaceStateParams{RateBps: 1000000, RatePacketOverhead: -4, RateCellSize: 64},
+	}, {
+		desc: "64-bit rate",
+		in:   &apb.InterfaceStateParams{RateBps: 1 << 40},
+	}, {
+		desc: "delay distribution",
+		in:   &apb.InterfaceStateParams{LatencyMsec: 100, JitterMsec: 10, DelayDistribution: apb.DelayDistribution_DD_PARETONORMAL},
+	}, {
+		desc: "Gilbert-Elliott loss model",
+		in: &apb.InterfaceStateParams{LossModel: &apb.InterfaceStateParams_GilbertElliott{
+			GilbertElliott: &apb.GilbertElliottLossModel{PPpm: 1000, RPpm: 500000, BadLossPpm: 900000, GoodLossPpm: 10},
+		}},
+	}, {
+		desc: "4-state loss model",
+		in: &apb.InterfaceStateParams{LossModel: &apb.InterfaceStateParams_FourState{
+			FourState: &apb.FourStateLossModel{P13Ppm: 1000, P31Ppm: 500000, P32Ppm: 100, P14Ppm: 10, P23Ppm: 200000},
+		}},
+	}, {
+		desc: "ingress",
+		in:   &apb.InterfaceStateParams{LatencyMsec: 20, LossPct: 1, Direction: apb.Direction_DIR_INGRESS},
+	}, {
+		desc: "both directions",
+		in:   &apb.InterfaceStateParams{LatencyMsec: 20, LossPpm: 10, Direction: apb.Direction_DIR_BOTH},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, _ := newFakeServer(t)
+			in := proto.Clone(tt.in).(*apb.InterfaceStateParams)
+			in.State = apb.InterfaceState_IS_UP
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: in})
+
+			want := tt.want
+			if want == nil {
+				want = tt.in
+			}
+			want = proto.Clone(want).(*apb.InterfaceStateParams)
+			want.State = apb.InterfaceState_IS_UP
+			want.Direction = tt.in.Direction
+			if got := mustGet(t, s, "eth1").GetParams(); !proto.Equal(got, want) {
+				t.Errorf("GetInterface(): did not get expected params, got: %s, want: %s", prototext.Format(got), prototext.Format(want))
+			}
+		})
+	}
+}
+
+func TestSetInterfaceProfile(t *testing.T) {
+	satellite := &apb.InterfaceStateParams{
+		State:       apb.InterfaceState_IS_UP,
+		LatencyMsec: 600,
+		JitterMsec:  50,
+		LossPct:     2,
+		Direction:   apb.Direction_DIR_EGRESS,
+	}
+
+	tests := []struct {
+		desc     string
+		in       *apb.SetInterfaceRequest
+		want     *apb.InterfaceStateParams
+		wantCode codes.Code
+	}{{
+		desc: "profile only",
+		in:   &apb.SetInterfaceRequest{Name: "eth1", ProfileName: "satellite"},
+		want: satellite,
+	}, {
+		desc: "profile with override",
+		in: &apb.SetInterfaceRequest{
+			Name:        "eth1",
+			ProfileName: "satellite",
+			Params:      &apb.InterfaceStateParams{LatencyMsec: 300, Direction: apb.Direction_DIR_BOTH},
+		},
+		want: &apb.InterfaceStateParams{
+			State:       apb.InterfaceState_IS_UP,
+			LatencyMsec: 300,
+			JitterMsec:  50,
+			LossPct:     2,
+			Direction:   apb.Direction_DIR_BOTH,
+		},
+	}, {
+		// A field of the profile cannot be overridden to its zero value.
+		desc: "profile with zero override",
+		in: &apb.SetInterfaceRequest{
+			Name:        "eth1",
+			ProfileName: "satellite",
+			Params:      &apb.InterfaceStateParams{LossPct: 0},
+		},
+		want: satellite,
+	}, {
+		desc: "override makes profile invalid",
+		in: &apb.SetInterfaceRequest{
+			Name:        "eth1",
+			ProfileName: "satellite",
+			Params:      &apb.InterfaceStateParams{LossPpm: 100},
+		},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "unknown profile",
+		in:       &apb.SetInterfaceRequest{Name: "eth1", ProfileName: "dial-up", Params: satellite},
+		wantCode: codes.NotFound,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b := fake.New()
+			b.AddLink("eth1", true)
+			s, err := New(WithBackend(b), WithProfiles(map[string]*apb.InterfaceStateParams{"satellite": satellite}))
+			if err != nil {
+				t.Fatalf("cannot create server, %v", err)
+			}
+
+			_, err = s.SetInterface(context.Background(), tt.in)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("SetInterface(): did not get expected error code, got: %s (%v), want: %s", got, err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if got := mustGet(t, s, "eth1").GetParams(); !proto.Equal(got, tt.want) {
+				t.Errorf("GetInterface(): did not get expected params, got: %s, want: %s", prototext.Format(got), prototext.Format(tt.want))
+			}
+			// The profile is not modified by the override.
+			if got := s.profiles["satellite"]; !proto.Equal(got, satellite) {
+				t.Errorf("SetInterface(): profile modified by request, got: %s, want: %s", prototext.Format(got), prototext.Format(satellite))
+			}
+		})
+	}
+}
+
+func TestSetInterfaceEncoding(t *testing.T) {
+	s, b := newFakeServer(t)
+	mustSet(t, s, &apb.SetInterfaceRequest{
+		Name: "eth1",
+		Params: &apb.InterfaceStateParams{
+			State:       apb.InterfaceState_IS_UP,
+			LatencyMsec: 50,
+			JitterMsec:  5,
+			LossPpm:     250000,
+		},
+	})
+
+	root := b.Qdiscs("eth1")[tc.HandleRoot]
+	if root == nil || root.Kind != "netem" {
+		t.Fatalf("did not get netem root qdisc, got: %v", root)
+	}
+	n, err := unmarshalNetem(root.Options)
+	if err != nil {
+		t.Fatalf("cannot decode netem options, %v", err)
+	}
+	// Latency is programmed in CPU ticks, and loss as a proportion of MaxUint32,
+	// rounded to the nearest integer.
+	want := tc.NetemQopt{
+		Latency: core.Time2Tick(50000),
+		Jitter:  core.Time2Tick(5000),
+		Limit:   1000,
+		Loss:    0x40000000,
+	}
+	if n.Qopt != want {
+		t.Errorf("did not get expected netem options, got: %+v, want: %+v", n.Qopt, want)
+	}
+}
+
+func TestOperDownEmulated(t *testing.T) {
+	// eth0 does not support its carrier being set, and hence is made to appear
+	// operationally down by dropping all packets in each direction, even though
+	// only egress impairments are requested.
+	s, b := newFakeServer(t)
+	mustSet(t, s, &apb.SetInterfaceRequest{
+		Name: "eth0",
+		Params: &apb.InterfaceStateParams{
+			State:       apb.InterfaceState_IS_OPER_DOWN,
+			LatencyMsec: 10,
+			LossPct:     1,
+			Direction:   apb.Direction_DIR_EGRESS,
+		},
+	})
+
+	eth, err := b.LinkByName("eth0")
+	if err != nil {
+		t.Fatalf("cannot find eth0, %v", err)
+	}
+	ifb := ifbName(eth.Index)
+	if to, ok := b.Redirect("eth0"); !ok || to != ifb {
+		t.Fatalf("ingress traffic not redirected to %s, got: %s (%v)", ifb, to, ok)
+	}
+
+	for _, dev := range []string{"eth0", ifb} {
+		root := b.Qdiscs(dev)[tc.HandleRoot]
+		if root == nil || root.Kind != "netem" {
+			t.Errorf("did not get netem root qdisc on %s, got: %v", dev, root)
+			continue
+		}
+		n, err := unmarshalNetem(root.Options)
+		if err != nil {
+			t.Fatalf("cannot decode netem options on %s, %v", dev, err)
+		}
+		if n.Qopt.Loss != MaxUint32 {
+			t.Errorf("%s does not drop all packets, got loss: %#x, want: %#x", dev, n.Qopt.Loss, MaxUint32)
+		}
+	}
+
+	if st := mustGet(t, s, "eth0").GetParams().GetState(); st != apb.InterfaceState_IS_OPER_DOWN {
+		t.Errorf("GetInterface(): did not get expected state, got: %s, want: %s", st, apb.InterfaceState_IS_OPER_DOWN)
+	}
+}
+
+func TestClearInterface(t *testing.T) {
+	tests := []struct {
+		desc string
+		name string
+		// original indicates that the interface has a root qdisc before it is
+		// impaired.
+		original bool
+		in       *apb.InterfaceStateParams
+	}{{
+		desc: "default qdisc",
+		name: "eth1",
+		in:   &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10},
+	}, {
+		desc:     "original qdisc",
+		name:     "eth1",
+		original: true,
+		in:       &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10},
+	}, {
+		desc:     "both directions",
+		name:     "eth1",
+		original: true,
+		in:       &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10, Direction: apb.Direction_DIR_BOTH},
+	}, {
+		desc: "carrier removed",
+		name: "eth1",
+		in:   &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN},
+	}, {
+		desc: "carrier emulated",
+		name: "eth0",
+		in:   &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b := fake.New()
+			index := b.AddLink(tt.name, tt.name == "eth1")
+			orig := tc.Msg{
+				Ifindex: uint32(index),
+				Handle:  core.BuildHandle(0x8001, 0x0),
+				Parent:  tc.HandleRoot,
+			}
+			origOpts := []byte{0x1, 0x2, 0x3, 0x4}
+			if tt.original {
+				if err := b.QdiscReplace(orig, "fq_codel", origOpts); err != nil {
+					t.Fatalf("cannot install original qdisc, %v", err)
+				}
+			}
+			s, err := New(WithBackend(b))
+			if err != nil {
+				t.Fatalf("cannot create server, %v", err)
+			}
+
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: tt.name, Params: tt.in})
+			if _, err := s.ClearInterface(context.Background(), &apb.ClearInterfaceRequest{Name: tt.name}); err != nil {
+				t.Fatalf("ClearInterface(): cannot clear interface, %v", err)
+			}
+
+			qdiscs := b.Qdiscs(tt.name)
+			switch root := qdiscs[tc.HandleRoot]; {
+			case !tt.original && root != nil:
+				t.Errorf("ClearInterface(): root qdisc not removed, got: %s", root.Kind)
+			case tt.original && (root == nil || root.Kind != "fq_codel" || root.Handle != orig.Handle || !bytes.Equal(root.Options, origOpts)):
+				t.Errorf("ClearInterface(): original qdisc not restored, got: %v", root)
+			}
+			if q := qdiscs[tc.HandleIngress]; q != nil {
+				t.Errorf("ClearInterface(): ingress qdisc not removed, got: %v", q)
+			}
+			if to, ok := b.Redirect(tt.name); ok {
+				t.Errorf("ClearInterface(): ingress traffic still redirected to %s", to)
+			}
+			if _, err := b.LinkByName(ifbName(index)); err == nil {
+				t.Errorf("ClearInterface(): ifb device %s not removed", ifbName(index))
+			}
+
+			got := mustGet(t, s, tt.name)
+			if st := got.GetParams().GetState(); st != apb.InterfaceState_IS_UP {
+				t.Errorf("GetInterface(): did not get expected state after clear, got: %s, want: %s", st, apb.InterfaceState_IS_UP)
+			}
+			if os := got.GetInterface().GetOperState(); os != apb.OperState_OS_UP {
+				t.Errorf("GetInterface(): did not get expected operational state after clear, got: %s, want: %s", os, apb.OperState_OS_UP)
+			}
+		})
+	}
+}
+
+// countingBackend is a fake backend that counts the calls that change the state of
+// links or their qdiscs.
+type countingBackend struct {
+	*fake.Backend
+	// changes is the number of calls that change state.
+	changes int
+}
+
+func (c *countingBackend) LinkSetState(name string, up bool) error {
+	c.changes++
+	return c.Backend.LinkSetState(name, up)
+}
+
+func (c *countingBackend) LinkSetCarrier(index int, up bool) error {
+	c.changes++
+	return c.Backend.LinkSetCarrier(index, up)
+}
+
+func (c *countingBackend) LinkAddIfb(name string) error {
+	c.changes++
+	return c.Backend.LinkAddIfb(name)
+}
+
+func (c *countingBackend) LinkDel(name string) error {
+	c.changes++
+	return c.Backend.LinkDel(name)
+}
+
+func (c *countingBackend) QdiscReplace(msg tc.Msg, kind string, options []byte) error {
+	c.changes++
+	return c.Backend.QdiscReplace(msg, kind, options)
+}
+
+func (c *countingBackend) QdiscDel(msg tc.Msg) error {
+	c.changes++
+	return c.Backend.QdiscDel(msg)
+}
+
+func (c *countingBackend) FilterAdd(msg tc.Msg, kind string, options []byte) error {
+	c.changes++
+	return c.Backend.FilterAdd(msg, kind, options)
+}
+
+func (c *countingBackend) RedirectIngress(index, target int) error {
+	c.changes++
+	return c.Backend.RedirectIngress(index, target)
+}
+
+func TestSetInterfaceChanged(t *testing.T) {
+	flows := []*apb.FlowImpairment{{
+		Match:  &apb.FlowMatch{IpProtocol: 6, DstPort: 179},
+		Params: &apb.InterfaceStateParams{LatencyMsec: 100},
+	}, {
+		Match:  &apb.FlowMatch{DstPrefix: "192.0.2.0/24"},
+		Params: &apb.InterfaceStateParams{LossPct: 10},
+	}}
+	ge := &apb.InterfaceStateParams_GilbertElliott{GilbertElliott: &apb.GilbertElliottLossModel{PPpm: 100, RPpm: 10000}}
+
+	bases := []struct {
+		desc string
+		in   *apb.SetInterfaceRequest
+		// noop indicates that the request does not change an interface that Aite
+		// has not programmed.
+		noop bool
+		// unchanged are the changes that do not alter the programmed state of
+		// the interface.
+		unchanged map[string]bool
+	}{{
+		desc: "up",
+		in:   &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP}},
+		noop: true,
+		// No qdisc is installed where no impairment is requested.
+		unchanged: map[string]bool{
+			"latency correlation": true,
+			"direction":           true,
+		},
+	}, {
+		desc: "egress",
+		in:   &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50, LossPct: 2}},
+	}, {
+		desc: "ingress",
+		in: &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{
+			State:       apb.InterfaceState_IS_UP,
+			LatencyMsec: 50,
+			LossPpm:     100,
+			Direction:   apb.Direction_DIR_INGRESS,
+		}},
+	}, {
+		desc: "both directions",
+		in: &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{
+			State:       apb.InterfaceState_IS_UP,
+			LatencyMsec: 50,
+			Direction:   apb.Direction_DIR_BOTH,
+		}},
+	}, {
+		desc: "delay distribution",
+		in: &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{
+			State:             apb.InterfaceState_IS_UP,
+			LatencyMsec:       50,
+			JitterMsec:        10,
+			DelayDistribution: apb.DelayDistribution_DD_PARETO,
+		}},
+	}, {
+		desc: "64-bit rate and loss model",
+		in: &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{
+			State:     apb.InterfaceState_IS_UP,
+			RateBps:   1 << 40,
+			LossModel: ge,
+		}},
+	}, {
+		desc: "flows",
+		in: &apb.SetInterfaceRequest{
+			Name:   "eth1",
+			Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10},
+			Flows:  flows,
+		},
+	}, {
+		desc: "flows in both directions",
+		in: &apb.SetInterfaceRequest{
+			Name:   "eth1",
+			Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 10, Direction: apb.Direction_DIR_BOTH},
+			Flows:  flows,
+		},
+	}, {
+		desc: "admin down",
+		in:   &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}},
+		unchanged: map[string]bool{
+			"latency correlation": true,
+			"direction":           true,
+		},
+	}, {
+		desc: "oper down",
+		in:   &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN, LatencyMsec: 5}},
+	}, {
+		desc: "oper down without carrier support",
+		in:   &apb.SetInterfaceRequest{Name: "eth0", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN, LatencyMsec: 5}},
+		// The interface drops all packets in each direction in place of its loss,
+		// flows and direction.
+		unchanged: map[string]bool{
+			"direction":       true,
+			"loss ppm":        true,
+			"loss model":      true,
+			"flow added":      true,
+			"flow match":      true,
+			"flow impairment": true,
+		},
+	}}
+
+	// Each change alters a single field of a request, and must result in the
+	// interface being reprogrammed unless the base specifies otherwise.
+	changes := []struct {
+		desc   string
+		change func(r *apb.SetInterfaceRequest)
+	}{
+		{"latency", func(r *apb.SetInterfaceRequest) { r.Params.LatencyMsec++ }},
+		{"jitter", func(r *apb.SetInterfaceRequest) { r.Params.JitterMsec++ }},
+		{"latency correlation", func(r *apb.SetInterfaceRequest) { r.Params.LatencyCorrelationPct++ }},
+		{"loss ppm", func(r *apb.SetInterfaceRequest) {
+			r.Params.LossPct, r.Params.LossModel, r.Params.LossPpm = 0, nil, r.Params.LossPpm+1
+		}},
+		{"duplication", func(r *apb.SetInterfaceRequest) { r.Params.DuplicatePct++ }},
+		{"corruption", func(r *apb.SetInterfaceRequest) { r.Params.CorruptPct++ }},
+		{"reordering", func(r *apb.SetInterfaceRequest) {
+			// Packets can only be reordered if they are delayed.
+			r.Params.ReorderPct++
+			if r.Params.LatencyMsec == 0 {
+				r.Params.LatencyMsec = 1
+			}
+		}},
+		{"rate", func(r *apb.SetInterfaceRequest) { r.Params.RateBps += 8 }},
+		{"loss model", func(r *apb.SetInterfaceRequest) {
+			r.Params.LossPct, r.Params.LossPpm = 0, 0
+			r.Params.LossModel = &apb.InterfaceStateParams_FourState{FourState: &apb.FourStateLossModel{P13Ppm: 1}}
+		}},
+		{"delay distribution", func(r *apb.SetInterfaceRequest) {
+			r.Params.JitterMsec = 1
+			r.Params.DelayDistribution = (r.Params.DelayDistribution + 1) % 4
+		}},
+		{"direction", func(r *apb.SetInterfaceRequest) { r.Params.Direction = (r.Params.Direction + 1) % 3 }},
+		// The states cycle from up, to admin down, to oper down.
+		{"state", func(r *apb.SetInterfaceRequest) { r.Params.State = r.Params.State%3 + 1 }},
+		{"flow added", func(r *apb.SetInterfaceRequest) {
+			r.Flows = append(r.Flows, &apb.FlowImpairment{Match: &apb.FlowMatch{VlanId: 100}, Params: &apb.InterfaceStateParams{}})
+		}},
+		{"flow match", func(r *apb.SetInterfaceRequest) {
+			if len(r.Flows) == 0 {
+				r.Flows = []*apb.FlowImpairment{{Params: &apb.InterfaceStateParams{}}}
+			}
+			r.Flows[0].Match = &apb.FlowMatch{IpProtocol: 17}
+		}},
+		{"flow impairment", func(r *apb.SetInterfaceRequest) {
+			if len(r.Flows) == 0 {
+				r.Flows = []*apb.FlowImpairment{{Params: &apb.InterfaceStateParams{}}}
+			}
+			r.Flows[0].Params.LatencyMsec++
+		}},
+	}
+
+	// set applies req to the server s using the backend b, and checks whether the
+	// interface was reported to be changed, and whether any change was made.
+	set := func(t *testing.T, s *S, b *countingBackend, req *apb.SetInterfaceRequest, wantChanged bool) {
+		t.Helper()
+		b.changes = 0
+		if got := mustSet(t, s, req).GetChanged(); got != wantChanged {
+			t.Errorf("SetInterface(%s): did not get expected changed, got: %v, want: %v", prototext.Format(req), got, wantChanged)
+		}
+		if !wantChanged && b.changes != 0 {
+			t.Errorf("SetInterface(%s): interface reprogrammed for request that does not change it, got: %d changes", prototext.Format(req), b.changes)
+		}
+	}
+
+	for _, base := range bases {
+		t.Run(base.desc, func(t *testing.T) {
+			newServer := func(t *testing.T) (*S, *countingBackend) {
+				s, f := newFakeServer(t)
+				b := &countingBackend{Backend: f}
+				s.backend = b
+				return s, b
+			}
+
+			s, b := newServer(t)
+			set(t, s, b, base.in, !base.noop)
+			set(t, s, b, base.in, false)
+			set(t, s, b, base.in, false)
+
+			for _, c := range changes {
+				t.Run(c.desc, func(t *testing.T) {
+					s, b := newServer(t)
+					set(t, s, b, base.in, !base.noop)
+
+					req := proto.Clone(base.in).(*apb.SetInterfaceRequest)
+					c.change(req)
+					set(t, s, b, req, !base.unchanged[c.desc])
+					set(t, s, b, req, false)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/florianl/go-tc"
+
+	apb "github.com/openconfig/aite/proto/aite"
+)
+
+// GetInterfaceStats implements the GetInterfaceStats RPC for the Aite service. It
+// reads the counters of the interface, and the statistics of the qdiscs installed
+// on it and on its ifb device, from the kernel.
+func (s *S) GetInterfaceStats(ctx context.Context, req *apb.GetInterfaceStatsRequest) (*apb.GetInterfaceStatsResponse, error) {
+	if !s.validInterface(req.Name) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid interface name specified, %s", req.Name)
+	}
+
+	attrs, egress, ingress, err := s.linkState(req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get interface %s, %v", req.Name, err)
+	}
+
+	resp := &apb.GetInterfaceStatsResponse{
+		Interface: interfaceProto(attrs, egress[tc.HandleRoot]),
+		Counters:  &apb.InterfaceCounters{},
+	}
+	if c := attrs.Statistics; c != nil {
+		resp.Counters = &apb.InterfaceCounters{
+			RxPackets: c.RxPackets,
+			TxPackets: c.TxPackets,
+			RxBytes:   c.RxBytes,
+			TxBytes:   c.TxBytes,
+			RxErrors:  c.RxErrors,
+			TxErrors:  c.TxErrors,
+			RxDropped: c.RxDropped,
+			TxDropped: c.TxDropped,
+		}
+	}
+	resp.Qdiscs = append(qdiscStatsProtos(req.Name, egress), qdiscStatsProtos(ifbName(attrs.Index), ingress)...)
+	return resp, nil
+}
+
+// qdiscStatsProtos returns the statistics of each qdisc in tree, which are installed
+// on the device with the specified name and keyed by the handle of their parent,
+// ordered by the handle of their parent.
+func qdiscStatsProtos(device string, tree map[uint32]*qdisc) []*apb.QdiscStats {
+	parents := []uint32{}
+	for p := range tree {
+		parents = append(parents, p)
+	}
+	sort.Slice(parents, func(i, j int) bool { return parents[i] < parents[j] })
+
+	stats := []*apb.QdiscStats{}
+	for _, p := range parents {
+		q := tree[p]
+		qs := &apb.QdiscStats{
+			Device: device,
+			Qdisc: &apb.Qdisc{
+				Kind:   q.Kind,
+				Handle: handleString(q.Handle),
+				Parent: handleString(q.Parent),
+			},
+		}
+		if st := q.Stats; st != nil {
+			qs.Bytes = st.Bytes
+			qs.Packets = st.Packets
+			qs.Drops = st.Drops
+			qs.Overlimits = st.Overlimits
+			qs.Requeues = st.Requeues
+			qs.BacklogBytes = st.Backlog
+			qs.Qlen = st.Qlen
+		}
+		stats = append(stats, qs)
+	}
+	return stats
+}
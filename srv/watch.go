@@ -0,0 +1,203 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog"
+
+	"github.com/florianl/go-tc"
+	"github.com/vishvananda/netlink"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv/backend"
+)
+
+// WatchInterfaces implements the WatchInterfaces RPC for the Aite service. It
+// subscribes to notifications of changes to links and qdiscs from the backend,
+// streaming an event for each that describes a change to a watched interface.
+func (s *S) WatchInterfaces(req *apb.WatchInterfacesRequest, stream apb.Aite_WatchInterfacesServer) error {
+	names := map[string]bool{}
+	for _, n := range req.Names {
+		if !s.validInterface(n) {
+			return status.Errorf(codes.InvalidArgument, "invalid interface name specified, %s", n)
+		}
+		names[n] = true
+	}
+
+	// The subscription is made before the current state is read, such that no
+	// change is missed between the two.
+	sub, err := s.backend.Subscribe()
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot subscribe to interface notifications, %v", err)
+	}
+	defer sub.Close()
+
+	w, err := s.newWatcher()
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot read interface state, %v", err)
+	}
+
+	if err := stream.Send(&apb.WatchInterfacesResponse{
+		Type:      apb.EventType_ET_SYNC,
+		Timestamp: time.Now().UnixNano(),
+	}); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	for {
+		notifications, err := sub.Receive(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return status.FromContextError(ctx.Err()).Err()
+		case errors.Is(err, backend.ErrNotificationsDropped):
+			// Once notifications are dropped, the stream can no longer report
+			// every change.
+			return status.Errorf(codes.ResourceExhausted, "cannot report every change, %v", err)
+		case err != nil:
+			return status.Errorf(codes.Internal, "cannot receive interface notifications, %v", err)
+		}
+
+		now := time.Now().UnixNano()
+		for _, n := range notifications {
+			ev, err := w.event(n)
+			if err != nil {
+				klog.Errorf("cannot parse notification, %v", err)
+				continue
+			}
+			if ev == nil || (len(names) != 0 && !names[ev.Interface.GetName()]) {
+				continue
+			}
+			ev.Timestamp = now
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watcher tracks the state of the links within the namespace, such that link
+// notifications that do not change a watched attribute can be discarded, and the
+// interface to which a qdisc notification relates can be reported.
+type watcher struct {
+	// links is the last known attributes of each link, keyed by index.
+	links map[int]*netlink.LinkAttrs
+	// roots is the qdisc installed at the root of each link, keyed by index.
+	roots map[uint32]*qdisc
+}
+
+// newWatcher returns a watcher initialised with the current state of the namespace.
+func (s *S) newWatcher() (*watcher, error) {
+	links, err := s.backend.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list interfaces, %v", err)
+	}
+
+	qdiscs, err := s.dumpQdiscs()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &watcher{
+		links: map[int]*netlink.LinkAttrs{},
+		roots: map[uint32]*qdisc{},
+	}
+	for _, attrs := range links {
+		w.links[attrs.Index] = attrs
+	}
+	for _, q := range qdiscs {
+		if q.Parent == tc.HandleRoot {
+			w.roots[q.Ifindex] = q
+		}
+	}
+	return w, nil
+}
+
+// event updates the watcher's state from the notification n, returning the event
+// that describes it, or nil if it does not describe a reportable change.
+func (w *watcher) event(n *backend.Notification) (*apb.WatchInterfacesResponse, error) {
+	switch n.Type {
+	case unix.RTM_NEWLINK, unix.RTM_DELLINK:
+		attrs := n.Link
+		root := w.roots[uint32(attrs.Index)]
+
+		if n.Type == unix.RTM_DELLINK {
+			delete(w.links, attrs.Index)
+			delete(w.roots, uint32(attrs.Index))
+			return &apb.WatchInterfacesResponse{
+				Type:      apb.EventType_ET_LINK_REMOVED,
+				Interface: interfaceProto(attrs, root),
+			}, nil
+		}
+
+		old := w.links[attrs.Index]
+		w.links[attrs.Index] = attrs
+		t := apb.EventType_ET_LINK_CHANGED
+		switch {
+		case old == nil:
+			t = apb.EventType_ET_LINK_ADDED
+		case old.Name == attrs.Name && old.Flags&net.FlagUp == attrs.Flags&net.FlagUp &&
+			old.OperState == attrs.OperState && old.MTU == attrs.MTU:
+			// The kernel notifies of changes to attributes that are not
+			// reported, such as addresses and statistics.
+			return nil, nil
+		}
+		return &apb.WatchInterfacesResponse{
+			Type:      t,
+			Interface: interfaceProto(attrs, root),
+		}, nil
+
+	case unix.RTM_NEWQDISC, unix.RTM_DELQDISC:
+		q, err := newQdisc(n.Qdisc)
+		if err != nil {
+			return nil, err
+		}
+		attrs := w.links[int(q.Ifindex)]
+		if attrs == nil {
+			// The qdiscs of a link are removed after the link itself.
+			return nil, nil
+		}
+
+		t := apb.EventType_ET_QDISC_CHANGED
+		if n.Type == unix.RTM_DELQDISC {
+			t = apb.EventType_ET_QDISC_REMOVED
+		}
+		if q.Parent == tc.HandleRoot {
+			if t == apb.EventType_ET_QDISC_REMOVED {
+				delete(w.roots, q.Ifindex)
+			} else {
+				w.roots[q.Ifindex] = q
+			}
+		}
+		return &apb.WatchInterfacesResponse{
+			Type:      t,
+			Interface: interfaceProto(attrs, w.roots[q.Ifindex]),
+			Qdisc: &apb.Qdisc{
+				Kind:   q.Kind,
+				Handle: handleString(q.Handle),
+				Parent: handleString(q.Parent),
+			},
+		}, nil
+	}
+	return nil, nil
+}
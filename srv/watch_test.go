@@ -0,0 +1,223 @@
+// Copyright 2023 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	apb "github.com/openconfig/aite/proto/aite"
+	"github.com/openconfig/aite/srv/backend"
+	"github.com/openconfig/aite/srv/backend/fake"
+)
+
+// droppingBackend is a fake backend whose subscriptions report that notifications
+// have been dropped.
+type droppingBackend struct {
+	*fake.Backend
+}
+
+func (*droppingBackend) Subscribe() (backend.Subscription, error) {
+	return droppingSubscription{}, nil
+}
+
+type droppingSubscription struct{}
+
+func (droppingSubscription) Receive(context.Context) ([]*backend.Notification, error) {
+	return nil, fmt.Errorf("buffer full, %w", backend.ErrNotificationsDropped)
+}
+
+func (droppingSubscription) Close() error { return nil }
+
+// trimEvent returns a copy of ev without the fields that are specific to the
+// links of the fake backend, or to the time at which it was received.
+func trimEvent(ev *apb.WatchInterfacesResponse) *apb.WatchInterfacesResponse {
+	ev = proto.Clone(ev).(*apb.WatchInterfacesResponse)
+	ev.Timestamp = 0
+	if i := ev.Interface; i != nil {
+		i.Index, i.Mac, i.Mtu = 0, "", 0
+		if i.RootQdisc != nil {
+			i.RootQdisc.Handle = ""
+		}
+	}
+	if ev.Qdisc != nil {
+		ev.Qdisc.Handle = ""
+	}
+	return ev
+}
+
+func TestWatchInterfaces(t *testing.T) {
+	netem := &apb.Qdisc{Kind: "netem"}
+	event := func(t apb.EventType, name string, admin apb.AdminState, oper apb.OperState, root *apb.Qdisc) *apb.WatchInterfacesResponse {
+		return &apb.WatchInterfacesResponse{
+			Type:      t,
+			Interface: &apb.Interface{Name: name, AdminState: admin, OperState: oper, RootQdisc: root},
+		}
+	}
+	withQdisc := func(ev *apb.WatchInterfacesResponse, kind, parent string) *apb.WatchInterfacesResponse {
+		ev.Qdisc = &apb.Qdisc{Kind: kind, Parent: parent}
+		return ev
+	}
+	impaired := &apb.InterfaceStateParams{State: apb.InterfaceState_IS_UP, LatencyMsec: 50, Direction: apb.Direction_DIR_EGRESS}
+
+	tests := []struct {
+		desc  string
+		names []string
+		// initial are the changes made to interfaces before the watch.
+		initial []*apb.SetInterfaceRequest
+		// change makes the changes that are reported by the watch.
+		change func(t *testing.T, s *S, b *fake.Backend)
+		want   []*apb.WatchInterfacesResponse
+	}{{
+		desc: "impairment applied",
+		change: func(t *testing.T, s *S, _ *fake.Backend) {
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: impaired})
+		},
+		want: []*apb.WatchInterfacesResponse{
+			withQdisc(event(apb.EventType_ET_QDISC_CHANGED, "eth1", apb.AdminState_AS_UP, apb.OperState_OS_UP, netem), "netem", "ffff:ffff"),
+		},
+	}, {
+		desc:    "impairment cleared",
+		initial: []*apb.SetInterfaceRequest{{Name: "eth1", Params: impaired}},
+		change: func(t *testing.T, s *S, _ *fake.Backend) {
+			if _, err := s.ClearInterface(context.Background(), &apb.ClearInterfaceRequest{Name: "eth1"}); err != nil {
+				t.Fatalf("ClearInterface(): cannot clear interface, %v", err)
+			}
+		},
+		want: []*apb.WatchInterfacesResponse{
+			withQdisc(event(apb.EventType_ET_QDISC_REMOVED, "eth1", apb.AdminState_AS_UP, apb.OperState_OS_UP, nil), "netem", "ffff:ffff"),
+		},
+	}, {
+		desc: "admin down",
+		change: func(t *testing.T, s *S, _ *fake.Backend) {
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}})
+		},
+		want: []*apb.WatchInterfacesResponse{
+			event(apb.EventType_ET_LINK_CHANGED, "eth1", apb.AdminState_AS_DOWN, apb.OperState_OS_DOWN, nil),
+		},
+	}, {
+		desc: "carrier removed",
+		change: func(t *testing.T, s *S, _ *fake.Backend) {
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_OPER_DOWN}})
+		},
+		want: []*apb.WatchInterfacesResponse{
+			event(apb.EventType_ET_LINK_CHANGED, "eth1", apb.AdminState_AS_UP, apb.OperState_OS_LOWER_LAYER_DOWN, nil),
+		},
+	}, {
+		desc: "link added and removed",
+		change: func(t *testing.T, _ *S, b *fake.Backend) {
+			b.AddLink("eth2", true)
+			if err := b.LinkDel("eth2"); err != nil {
+				t.Fatalf("cannot delete eth2, %v", err)
+			}
+		},
+		want: []*apb.WatchInterfacesResponse{
+			event(apb.EventType_ET_LINK_ADDED, "eth2", apb.AdminState_AS_UP, apb.OperState_OS_UP, nil),
+			event(apb.EventType_ET_LINK_REMOVED, "eth2", apb.AdminState_AS_UP, apb.OperState_OS_UP, nil),
+		},
+	}, {
+		desc:  "unwatched interface",
+		names: []string{"eth1"},
+		change: func(t *testing.T, s *S, _ *fake.Backend) {
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth0", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}})
+			mustSet(t, s, &apb.SetInterfaceRequest{Name: "eth1", Params: &apb.InterfaceStateParams{State: apb.InterfaceState_IS_ADMIN_DOWN}})
+		},
+		want: []*apb.WatchInterfacesResponse{
+			event(apb.EventType_ET_LINK_CHANGED, "eth1", apb.AdminState_AS_DOWN, apb.OperState_OS_DOWN, nil),
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, b := newFakeServer(t)
+			for _, r := range tt.initial {
+				mustSet(t, s, r)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			stream, err := newFakeClient(t, s).WatchInterfaces(ctx, &apb.WatchInterfacesRequest{Names: tt.names})
+			if err != nil {
+				t.Fatalf("WatchInterfaces(): cannot start watch, %v", err)
+			}
+			sync, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("WatchInterfaces(): did not get sync event, %v", err)
+			}
+			if sync.GetType() != apb.EventType_ET_SYNC {
+				t.Fatalf("WatchInterfaces(): did not get expected first event, got: %s, want: %s", sync.GetType(), apb.EventType_ET_SYNC)
+			}
+
+			tt.change(t, s, b)
+			for i, want := range tt.want {
+				ev, err := stream.Recv()
+				if err != nil {
+					t.Fatalf("WatchInterfaces(): did not get event %d, %v", i, err)
+				}
+				if ev.GetTimestamp() == 0 {
+					t.Errorf("WatchInterfaces(): event %d has no timestamp", i)
+				}
+				if got := trimEvent(ev); !proto.Equal(got, want) {
+					t.Errorf("WatchInterfaces(): did not get expected event %d, got: %s, want: %s", i, prototext.Format(got), prototext.Format(want))
+				}
+			}
+		})
+	}
+}
+
+func TestWatchInterfacesErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *apb.WatchInterfacesRequest
+		// dropped indicates that the backend drops notifications.
+		dropped  bool
+		wantCode codes.Code
+	}{{
+		desc:     "unknown interface",
+		in:       &apb.WatchInterfacesRequest{Names: []string{"eth1", "eth9"}},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "notifications dropped",
+		in:       &apb.WatchInterfacesRequest{},
+		dropped:  true,
+		wantCode: codes.ResourceExhausted,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, b := newFakeServer(t)
+			if tt.dropped {
+				s.backend = &droppingBackend{Backend: b}
+			}
+			stream, err := newFakeClient(t, s).WatchInterfaces(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("WatchInterfaces(): cannot start watch, %v", err)
+			}
+			for err == nil {
+				_, err = stream.Recv()
+			}
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("WatchInterfaces(): did not get expected error code, got: %s (%v), want: %s", got, err, tt.wantCode)
+			}
+		})
+	}
+}